@@ -12,9 +12,10 @@
 //   - Time is set as the Timestamp.
 //   - Message is set as the Body using an [attribute.StringValue].
 //   - Level is transformed and set as the Severity. The SeverityText is also
-//     set.
+//     set, see [WithLevelNames] to name custom levels.
 //   - PC is dropped.
-//   - Attr are transformed and set as the Attributes.
+//   - Attr are rewritten by the function configured with [WithReplaceAttr],
+//     if any, then transformed and set as the Attributes.
 //
 // The Level is transformed by using the static offset to the OpenTelemetry
 // Severity types. For example:
@@ -48,6 +49,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"maps"
 	"runtime"
 	"slices"
 
@@ -55,6 +57,7 @@ import (
 	"go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/log/global"
 	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // NewLogger returns a new [slog.Logger] backed by a new [Handler]. See
@@ -64,11 +67,14 @@ func NewLogger(name string, options ...Option) *slog.Logger {
 }
 
 type config struct {
-	provider   log.LoggerProvider
-	version    string
-	schemaURL  string
-	attributes []attribute.KeyValue
-	source     bool
+	provider    log.LoggerProvider
+	version     string
+	schemaURL   string
+	attributes  []attribute.KeyValue
+	source      bool
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr
+	levelNames  map[slog.Level]string
+	next        slog.Handler
 }
 
 func newConfig(options []Option) config {
@@ -157,6 +163,58 @@ func WithSource(source bool) Option {
 	})
 }
 
+// WithReplaceAttr returns an [Option] that configures the [Handler] to call
+// replace to rewrite each attribute before it is converted, with the same
+// semantics as [slog.HandlerOptions.ReplaceAttr]: the value passed to replace
+// is resolved, replace is not called for group attributes but for their
+// members with groups holding the names of the enclosing groups, and the
+// attribute is dropped if replace returns the zero [slog.Attr].
+//
+// Unlike [slog.HandlerOptions.ReplaceAttr], replace is not called for the
+// time, level, message and source of a record. These are not attributes of
+// the converted record.
+//
+// This can be used to rename attributes, or to redact the value of sensitive
+// attributes before they leave the process.
+func WithReplaceAttr(replace func(groups []string, a slog.Attr) slog.Attr) Option {
+	return optFunc(func(c config) config {
+		c.replaceAttr = replace
+		return c
+	})
+}
+
+// WithLevelNames returns an [Option] that configures the severity text of the
+// records with a level in names to the name of the level. The severity text
+// of the other records is the string representation of their level.
+//
+// This can be used to name the custom [slog.Level] of an application, for
+// example "TRACE" for slog.LevelDebug-4.
+func WithLevelNames(names map[slog.Level]string) Option {
+	return optFunc(func(c config) config {
+		c.levelNames = maps.Clone(names)
+		return c
+	})
+}
+
+// WithHandler returns an [Option] that configures the [Handler] to also pass
+// the records it handles to next, for example an [slog.JSONHandler] writing
+// to the standard output, so that the records are both sent to OpenTelemetry
+// and handled by next.
+//
+// The records are only sent to OpenTelemetry or passed to next if
+// respectively the [log.Logger] or next is enabled for their level. If the
+// context of a record holds a valid span context, the trace_id and span_id
+// attributes identifying the span are added to the record passed to next.
+//
+// The options of the Handler, such as [WithReplaceAttr], do not apply to the
+// records passed to next.
+func WithHandler(next slog.Handler) Option {
+	return optFunc(func(c config) config {
+		c.next = next
+		return c
+	})
+}
+
 // Handler is an [slog.Handler] that sends all logging records it receives to
 // OpenTelemetry. See package documentation for how conversions are made.
 type Handler struct {
@@ -167,7 +225,10 @@ type Handler struct {
 	group  *group
 	logger log.Logger
 
-	source bool
+	source      bool
+	replaceAttr func(groups []string, a slog.Attr) slog.Attr
+	levelNames  map[slog.Level]string
+	next        slog.Handler
 }
 
 // Compile-time check *Handler implements slog.Handler.
@@ -184,15 +245,54 @@ var _ slog.Handler = (*Handler)(nil)
 func NewHandler(name string, options ...Option) *Handler {
 	cfg := newConfig(options)
 	return &Handler{
-		logger: cfg.logger(name),
-		source: cfg.source,
+		logger:      cfg.logger(name),
+		source:      cfg.source,
+		replaceAttr: cfg.replaceAttr,
+		levelNames:  cfg.levelNames,
+		next:        cfg.next,
 	}
 }
 
 // Handle handles the passed record.
 func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
-	h.logger.Emit(ctx, h.convertRecord(record))
-	return nil
+	if h.next == nil {
+		h.logger.Emit(ctx, h.convertRecord(record))
+		return nil
+	}
+
+	if h.loggerEnabled(ctx, record.Level) {
+		h.logger.Emit(ctx, h.convertRecord(record))
+	}
+	if !h.next.Enabled(ctx, record.Level) {
+		return nil
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		// Do not modify the attributes of record shared with the caller.
+		record = record.Clone()
+		record.AddAttrs(
+			slog.String(traceIDKey, sc.TraceID().String()),
+			slog.String(spanIDKey, sc.SpanID().String()),
+		)
+	}
+	return h.next.Handle(ctx, record)
+}
+
+// Keys of the attributes identifying the span of a record passed to the
+// handler configured with WithHandler.
+const (
+	traceIDKey = "trace_id"
+	spanIDKey  = "span_id"
+)
+
+// newKVBuffer returns a kvBuffer holding the attributes of group g, or of the
+// record if g is nil, that applies the ReplaceAttr function of h.
+func (h *Handler) newKVBuffer(n int, g *group) *kvBuffer {
+	b := newKVBuffer(n)
+	if h.replaceAttr != nil {
+		b.replace = h.replaceAttr
+		b.groups = g.Names()
+	}
+	return b
 }
 
 func (h *Handler) convertRecord(r slog.Record) log.Record {
@@ -202,7 +302,11 @@ func (h *Handler) convertRecord(r slog.Record) log.Record {
 
 	const sevOffset = slog.Level(log.SeverityDebug) - slog.LevelDebug
 	record.SetSeverity(log.Severity(r.Level + sevOffset))
-	record.SetSeverityText(r.Level.String())
+	if name, ok := h.levelNames[r.Level]; ok {
+		record.SetSeverityText(name)
+	} else {
+		record.SetSeverityText(r.Level.String())
+	}
 
 	if h.source {
 		fs := runtime.CallersFrames([]uintptr{r.PC})
@@ -228,7 +332,7 @@ func (h *Handler) convertRecord(r slog.Record) log.Record {
 		}
 
 		if n > 0 {
-			buf := newKVBuffer(n)
+			buf := h.newKVBuffer(n, h.group)
 			r.Attrs(buf.AddAttr)
 			if buf.err != nil {
 				record.SetErr(buf.err)
@@ -250,7 +354,7 @@ func (h *Handler) convertRecord(r slog.Record) log.Record {
 			}
 		}
 	} else if n > 0 {
-		buf := newKVBuffer(n)
+		buf := h.newKVBuffer(n, nil)
 		r.Attrs(buf.AddAttr)
 		if buf.err != nil {
 			record.SetErr(buf.err)
@@ -264,6 +368,13 @@ func (h *Handler) convertRecord(r slog.Record) log.Record {
 // Enabled returns true if the Handler is enabled to log for the provided
 // context and Level. Otherwise, false is returned if it is not enabled.
 func (h *Handler) Enabled(ctx context.Context, l slog.Level) bool {
+	if h.next != nil && h.next.Enabled(ctx, l) {
+		return true
+	}
+	return h.loggerEnabled(ctx, l)
+}
+
+func (h *Handler) loggerEnabled(ctx context.Context, l slog.Level) bool {
 	const sevOffset = slog.Level(log.SeverityDebug) - slog.LevelDebug
 	param := log.EnabledParameters{Severity: log.Severity(l + sevOffset)}
 	return h.logger.Enabled(ctx, param)
@@ -275,15 +386,21 @@ func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 	h2 := *h
 	if h2.group != nil {
 		h2.group = h2.group.Clone()
+		if h2.group.attrs == nil {
+			h2.group.attrs = h.newKVBuffer(len(attrs), h2.group)
+		}
 		h2.group.AddAttrs(attrs)
 	} else {
 		if h2.attrs == nil {
-			h2.attrs = newKVBuffer(len(attrs))
+			h2.attrs = h.newKVBuffer(len(attrs), nil)
 		} else {
 			h2.attrs = h2.attrs.Clone()
 		}
 		h2.attrs.AddAttrs(attrs)
 	}
+	if h2.next != nil {
+		h2.next = h2.next.WithAttrs(attrs)
+	}
 	return &h2
 }
 
@@ -292,6 +409,9 @@ func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
 func (h *Handler) WithGroup(name string) slog.Handler {
 	h2 := *h
 	h2.group = &group{name: name, next: h2.group}
+	if h2.next != nil {
+		h2.next = h2.next.WithGroup(name)
+	}
 	return &h2
 }
 
@@ -359,6 +479,17 @@ func (g *group) Err() error {
 	return nil
 }
 
+// Names returns the names of the groups of g's linked-list, starting with the
+// outermost group. If g is nil, nil is returned.
+func (g *group) Names() []string {
+	var names []string
+	for ; g != nil; g = g.next {
+		names = append(names, g.name)
+	}
+	slices.Reverse(names)
+	return names
+}
+
 // NextNonEmpty returns the next group within g's linked-list that has
 // attributes (including g itself). If no group is found, nil is returned.
 func (g *group) NextNonEmpty() *group {
@@ -418,6 +549,10 @@ type kvBuffer struct {
 	// dedicated error slot like a record does, so a promoted error would be
 	// silently dropped.
 	keepErr bool
+	// replace, if not nil, is called to rewrite the attributes added to b,
+	// with groups the names of the groups holding them.
+	replace func(groups []string, a slog.Attr) slog.Attr
+	groups  []string
 }
 
 func newKVBuffer(n int) *kvBuffer {
@@ -437,7 +572,13 @@ func (b *kvBuffer) Clone() *kvBuffer {
 	if b == nil {
 		return nil
 	}
-	return &kvBuffer{data: slices.Clone(b.data), err: b.err, keepErr: b.keepErr}
+	return &kvBuffer{
+		data:    slices.Clone(b.data),
+		err:     b.err,
+		keepErr: b.keepErr,
+		replace: b.replace,
+		groups:  b.groups,
+	}
 }
 
 // KeyValues returns kvs appended to the [attribute.KeyValue] held by b. The
@@ -470,7 +611,38 @@ func (b *kvBuffer) AddAttrs(attrs []slog.Attr) {
 // If attr is a group with an empty key, its values will be flattened.
 //
 // If attr is empty, it will be dropped.
+//
+// If b has a replace function, attr is rewritten by it before being added,
+// and dropped if it is rewritten to an empty attribute.
 func (b *kvBuffer) AddAttr(attr slog.Attr) bool {
+	if b.replace != nil {
+		attr.Value = attr.Value.Resolve()
+		if attr.Value.Kind() == slog.KindGroup {
+			if attr.Key != "" {
+				// Replace the group members with the group name appended
+				// to the groups passed to replace.
+				buf := &kvBuffer{
+					keepErr: true,
+					replace: b.replace,
+					groups:  append(slices.Clip(b.groups), attr.Key),
+				}
+				buf.AddAttrs(attr.Value.Group())
+				// A Handler should ignore a group with no attributes, as
+				// it is when replace drops all its members.
+				if len(buf.data) > 0 {
+					b.data = append(b.data, attribute.Map(attr.Key, buf.data...))
+				}
+				return true
+			}
+		} else {
+			attr = b.replace(b.groups, attr)
+			attr.Value = attr.Value.Resolve()
+			if attr.Equal(slog.Attr{}) {
+				return true
+			}
+		}
+	}
+
 	if !b.keepErr && attr.Value.Kind() == slog.KindAny {
 		if err, ok := attr.Value.Any().(error); ok {
 			b.err = err
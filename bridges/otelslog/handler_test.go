@@ -8,7 +8,9 @@
 package otelslog
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -26,6 +28,7 @@ import (
 	"go.opentelemetry.io/otel/log/embedded"
 	"go.opentelemetry.io/otel/log/global"
 	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var now = time.Now()
@@ -474,6 +477,124 @@ func TestSlogtest(t *testing.T) {
 	})
 }
 
+func TestSlogtestReplaceAttr(t *testing.T) {
+	r := new(recorder)
+	slogtest.Run(t, func(*testing.T) slog.Handler {
+		r = new(recorder)
+		identity := func(_ []string, a slog.Attr) slog.Attr { return a }
+		return NewHandler("", WithLoggerProvider(r), WithReplaceAttr(identity))
+	}, func(*testing.T) map[string]any {
+		return r.Results()[0]
+	})
+}
+
+type secret string
+
+func (secret) LogValue() slog.Value { return slog.StringValue("resolved") }
+
+func TestHandlerReplaceAttr(t *testing.T) {
+	type call struct {
+		groups []string
+		attr   slog.Attr
+	}
+	var calls []call
+	replace := func(groups []string, a slog.Attr) slog.Attr {
+		calls = append(calls, call{groups: groups, attr: a})
+		switch a.Key {
+		case "ssn":
+			return slog.String("ssn", "REDACTED")
+		case "drop", "err":
+			return slog.Attr{}
+		case "old":
+			a.Key = "new"
+		}
+		return a
+	}
+
+	r := new(recorder)
+	l := slog.New(NewHandler("", WithLoggerProvider(r), WithReplaceAttr(replace)))
+	l = l.With("ssn", "123-45-6789").WithGroup("G").With("drop", 1)
+	l.Info("msg",
+		"old", 2,
+		slog.Any("valuer", secret("raw")),
+		slog.Group("H", "ssn", "987-65-4321", "drop", 3),
+		slog.Group("I", "drop", 4),
+		slog.Any("err", errors.New("redacted error")),
+	)
+
+	require.Len(t, r.Records, 1)
+	got := r.Results()[0]
+	assert.Equal(t, "REDACTED", got["ssn"])
+	assert.NotContains(t, got, "error", "replaced error should not be set")
+	assert.Equal(t, map[string]any{
+		"new":    int64(2),
+		"valuer": "resolved",
+		"H":      map[string]any{"ssn": "REDACTED"},
+	}, got["G"], "dropped attributes and empty groups should not be emitted")
+
+	assert.Contains(t, calls, call{groups: []string{"G", "H"}, attr: slog.String("ssn", "987-65-4321")})
+	assert.Contains(t, calls, call{groups: []string{"G"}, attr: slog.String("valuer", "resolved")}, "value should be resolved")
+	assert.Contains(t, calls, call{groups: nil, attr: slog.String("ssn", "123-45-6789")})
+}
+
+func TestHandlerLevelNames(t *testing.T) {
+	const levelTrace = slog.LevelDebug - 4
+	r := new(recorder)
+	r.MinSeverity = log.SeverityTrace1
+	l := slog.New(NewHandler("", WithLoggerProvider(r), WithLevelNames(map[slog.Level]string{
+		levelTrace: "TRACE",
+	})))
+
+	l.Log(t.Context(), levelTrace, "trace")
+	l.Info("info")
+
+	require.Len(t, r.Records, 2)
+	assert.Equal(t, log.SeverityTrace1, r.Records[0].Severity())
+	assert.Equal(t, "TRACE", r.Records[0].SeverityText())
+	assert.Equal(t, "INFO", r.Records[1].SeverityText())
+}
+
+func TestHandlerWithHandler(t *testing.T) {
+	r := new(recorder)
+	r.MinSeverity = log.SeverityWarn
+	var buf bytes.Buffer
+	next := slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})
+	h := NewHandler("", WithLoggerProvider(r), WithHandler(next))
+
+	ctx := t.Context()
+	assert.False(t, h.Enabled(ctx, slog.LevelDebug))
+	assert.True(t, h.Enabled(ctx, slog.LevelInfo), "next handler enabled")
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	l := slog.New(h).WithGroup("G").With("key", "value")
+	l.InfoContext(trace.ContextWithSpanContext(ctx, sc), "info")
+	l.WarnContext(ctx, "warn")
+
+	require.Len(t, r.Records, 1, "info record should not be emitted")
+	assert.Equal(t, "warn", r.Records[0].Body().AsString())
+
+	var got []map[string]any
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var m map[string]any
+		require.NoError(t, dec.Decode(&m))
+		got = append(got, m)
+	}
+	require.Len(t, got, 2)
+	assert.Equal(t, "info", got[0]["msg"])
+	assert.Equal(t, map[string]any{
+		"key":      "value",
+		"trace_id": sc.TraceID().String(),
+		"span_id":  sc.SpanID().String(),
+	}, got[0]["G"])
+	assert.Equal(t, "warn", got[1]["msg"])
+	assert.Equal(t, map[string]any{"key": "value"}, got[1]["G"])
+}
+
 func TestNewHandlerConfiguration(t *testing.T) {
 	name := "name"
 	t.Run("Default", func(t *testing.T) {
@@ -13,13 +13,16 @@
 //   - Message is set as the Body using an [attribute.StringValue].
 //   - Level is transformed and set as the Severity. The SeverityText is also
 //     set.
-//   - Fields are transformed and set as the Attributes.
+//   - Fields are replaced or dropped by the function configured with
+//     [WithReplaceField], if any, then transformed and set as the Attributes.
 //   - Fields of type [error] are attached to the emitted record as an error via
 //     [log.Record.SetErr].
 //   - Field value of type [context.Context] is used as context when emitting log records.
 //   - For named loggers, LoggerName is used to access [log.Logger] from [log.LoggerProvider]
 //
-// The Level is transformed to the OpenTelemetry Severity types in the following way.
+// The Level is transformed by using the [WithLevelSeverity] option. If the
+// option is not provided then the Level is transformed to the OpenTelemetry
+// Severity types in the following way.
 //
 //   - [zapcore.DebugLevel] is transformed to [log.SeverityDebug]
 //   - [zapcore.InfoLevel] is transformed to [log.SeverityInfo]
@@ -38,6 +41,7 @@ package otelzap
 import (
 	"context"
 	"slices"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/log"
@@ -52,10 +56,13 @@ var (
 )
 
 type config struct {
-	provider   log.LoggerProvider
-	version    string
-	schemaURL  string
-	attributes []attribute.KeyValue
+	provider      log.LoggerProvider
+	version       string
+	schemaURL     string
+	attributes    []attribute.KeyValue
+	replaceField  func(zapcore.Field) (zapcore.Field, bool)
+	levelSeverity func(zapcore.Level) log.Severity
+	sampler       zapcore.Core
 }
 
 func newConfig(options []Option) config {
@@ -67,6 +74,9 @@ func newConfig(options []Option) config {
 	if c.provider == nil {
 		c.provider = global.GetLoggerProvider()
 	}
+	if c.levelSeverity == nil {
+		c.levelSeverity = convertLevel
+	}
 
 	return c
 }
@@ -121,6 +131,72 @@ func WithLoggerProvider(provider log.LoggerProvider) Option {
 	})
 }
 
+// WithReplaceField returns an [Option] that configures the [Core] to call
+// replace with each field, including the fields added with [Core.With], before
+// it is converted. The field returned by replace is converted instead, unless
+// replace returns false, in which case the field is dropped.
+//
+// This can be used to drop or redact fields by key, so that sensitive values
+// never leave the process. The fields of the objects and arrays of a field are
+// not passed to replace. The context fields are not passed to replace.
+func WithReplaceField(replace func(zapcore.Field) (zapcore.Field, bool)) Option {
+	return optFunc(func(c config) config {
+		c.replaceField = replace
+		return c
+	})
+}
+
+// WithLevelSeverity returns an [Option] that configures the function used to
+// convert zap levels to OpenTelemetry log severities.
+//
+// By default if this Option is not provided, the Core will use the conversion
+// described in the package documentation.
+func WithLevelSeverity(f func(zapcore.Level) log.Severity) Option {
+	return optFunc(func(c config) config {
+		c.levelSeverity = f
+		return c
+	})
+}
+
+// WithSampling returns an [Option] that configures the [Core] to sample the
+// records it emits with the algorithm of [zapcore.NewSamplerWithOptions] and
+// the passed arguments: every tick, the first records with a given level and
+// message are emitted, then every thereafter-th record.
+//
+// Unlike wrapping the Core with [zapcore.NewSamplerWithOptions], this can be
+// configured separately from the sampling of the other cores of a logger, for
+// example a console output core combined with the Core using
+// [zapcore.NewTee], and is preserved by the cores returned by [Core.With]. The
+// hook configured with [zapcore.SamplerHook] is called with the sampling
+// decisions.
+func WithSampling(tick time.Duration, first, thereafter int, opts ...zapcore.SamplerOption) Option {
+	return optFunc(func(c config) config {
+		c.sampler = zapcore.NewSamplerWithOptions(sampledCore{}, tick, first, thereafter, opts...)
+		return c
+	})
+}
+
+// sampled is the entry returned by a sampler wrapping a sampledCore when an
+// entry is sampled. It is never written.
+var sampled = new(zapcore.CheckedEntry)
+
+// sampledCore is the zapcore.Core wrapped by the sampler of a Core. It
+// returns sampled for the entries sampled by the sampler so that the sampler
+// only decides which entries are emitted.
+type sampledCore struct{}
+
+func (sampledCore) Enabled(zapcore.Level) bool { return true }
+
+func (c sampledCore) With([]zapcore.Field) zapcore.Core { return c }
+
+func (sampledCore) Check(zapcore.Entry, *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return sampled
+}
+
+func (sampledCore) Write(zapcore.Entry, []zapcore.Field) error { return nil }
+
+func (sampledCore) Sync() error { return nil }
+
 // Core is a [zapcore.Core] that sends logging records to OpenTelemetry.
 type Core struct {
 	provider log.LoggerProvider
@@ -129,6 +205,10 @@ type Core struct {
 	attr     []attribute.KeyValue
 	ctx      context.Context
 	err      error
+
+	replaceField  func(zapcore.Field) (zapcore.Field, bool)
+	levelSeverity func(zapcore.Level) log.Severity
+	sampler       zapcore.Core
 }
 
 // Compile-time check *Core implements zapcore.Core.
@@ -154,16 +234,19 @@ func NewCore(name string, opts ...Option) *Core {
 	logger := cfg.provider.Logger(name, loggerOpts...)
 
 	return &Core{
-		provider: cfg.provider,
-		logger:   logger,
-		opts:     loggerOpts,
-		ctx:      context.Background(),
+		provider:      cfg.provider,
+		logger:        logger,
+		opts:          loggerOpts,
+		ctx:           context.Background(),
+		replaceField:  cfg.replaceField,
+		levelSeverity: cfg.levelSeverity,
+		sampler:       cfg.sampler,
 	}
 }
 
 // Enabled decides whether a given logging level is enabled when logging a message.
 func (o *Core) Enabled(level zapcore.Level) bool {
-	param := log.EnabledParameters{Severity: convertLevel(level)}
+	param := log.EnabledParameters{Severity: o.levelSeverity(level)}
 	return o.logger.Enabled(context.Background(), param)
 }
 
@@ -171,7 +254,7 @@ func (o *Core) Enabled(level zapcore.Level) bool {
 func (o *Core) With(fields []zapcore.Field) zapcore.Core {
 	cloned := o.clone()
 	if len(fields) > 0 {
-		ctx, attrbuf, err := convertField(fields)
+		ctx, attrbuf, err := convertField(fields, o.replaceField)
 		if ctx != nil {
 			cloned.ctx = ctx
 		}
@@ -191,6 +274,12 @@ func (o *Core) clone() *Core {
 		attr:     slices.Clone(o.attr),
 		ctx:      o.ctx,
 		err:      o.err,
+
+		replaceField:  o.replaceField,
+		levelSeverity: o.levelSeverity,
+		// The sampler is shared so that the records of the cores returned by
+		// With are counted together.
+		sampler: o.sampler,
 	}
 }
 
@@ -202,17 +291,20 @@ func (*Core) Sync() error {
 // Check determines whether the supplied Entry should be logged.
 // If the entry should be logged, the Core adds itself to the CheckedEntry and returns the result.
 func (o *Core) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
-	param := log.EnabledParameters{Severity: convertLevel(ent.Level)}
+	param := log.EnabledParameters{Severity: o.levelSeverity(ent.Level)}
 
 	logger := o.logger
 	if ent.LoggerName != "" {
 		logger = o.provider.Logger(ent.LoggerName, o.opts...)
 	}
 
-	if logger.Enabled(context.Background(), param) {
-		return ce.AddCore(ent, o)
+	if !logger.Enabled(context.Background(), param) {
+		return ce
+	}
+	if o.sampler != nil && o.sampler.Check(ent, nil) != sampled {
+		return ce
 	}
-	return ce
+	return ce.AddCore(ent, o)
 }
 
 // Write method encodes zap fields to OTel logs and emits them.
@@ -220,14 +312,14 @@ func (o *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
 	r := log.Record{}
 	r.SetTimestamp(ent.Time)
 	r.SetBody(attribute.StringValue(ent.Message))
-	r.SetSeverity(convertLevel(ent.Level))
+	r.SetSeverity(o.levelSeverity(ent.Level))
 	r.SetSeverityText(ent.Level.String())
 
 	emitCtx := o.ctx
 	recErr := o.err
 	var attrbuf []attribute.KeyValue
 	if len(fields) > 0 {
-		ctx, converted, err := convertField(fields)
+		ctx, converted, err := convertField(fields, o.replaceField)
 		if ctx != nil {
 			emitCtx = ctx
 		}
@@ -265,7 +357,7 @@ func (o *Core) Write(ent zapcore.Entry, fields []zapcore.Field) error {
 	return nil
 }
 
-func convertField(fields []zapcore.Field) (context.Context, []attribute.KeyValue, error) {
+func convertField(fields []zapcore.Field, replace func(zapcore.Field) (zapcore.Field, bool)) (context.Context, []attribute.KeyValue, error) {
 	var ctx context.Context
 	enc := newObjectEncoder(len(fields))
 	var errField error
@@ -274,6 +366,12 @@ func convertField(fields []zapcore.Field) (context.Context, []attribute.KeyValue
 			ctx = ctxFld
 			continue
 		}
+		if replace != nil {
+			var keep bool
+			if field, keep = replace(field); !keep {
+				continue
+			}
+		}
 		if field.Type == zapcore.ErrorType && field.Key == "error" {
 			if err, ok := field.Interface.(error); ok && err != nil {
 				errField = err
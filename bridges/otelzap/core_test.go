@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/log"
@@ -680,3 +681,96 @@ func TestCoreMalformedErrorFieldDoesNotPanic(t *testing.T) {
 		})
 	}
 }
+
+func TestCoreReplaceField(t *testing.T) {
+	replace := func(f zapcore.Field) (zapcore.Field, bool) {
+		switch f.Key {
+		case "password", "error":
+			return f, false
+		case "ssn":
+			return zap.String(f.Key, "REDACTED"), true
+		}
+		return f, true
+	}
+
+	p := newCaptureProvider()
+	logger := zap.New(NewCore(loggerName, WithLoggerProvider(p), WithReplaceField(replace)))
+	logger = logger.With(zap.String("ssn", "123-45-6789"), zap.String("password", "secret"))
+	logger.Info(testMessage,
+		zap.String(testKey, testValue),
+		zap.Int("ssn", 987654321),
+		zap.Error(errTest),
+	)
+
+	r := p.logger.lastRecord(t)
+	require.NoError(t, r.err, "dropped error field should not be set")
+	require.Equal(t, []attribute.KeyValue{
+		attribute.String("ssn", "REDACTED"),
+		attribute.String(testKey, testValue),
+		attribute.String("ssn", "REDACTED"),
+	}, r.attrs)
+}
+
+func TestCoreLevelSeverity(t *testing.T) {
+	rec := logtest.NewRecorder(logtest.WithEnabledFunc(func(_ context.Context, param log.EnabledParameters) bool {
+		return param.Severity >= log.SeverityInfo
+	}))
+	levelSeverity := func(l zapcore.Level) log.Severity {
+		if l == zapcore.DebugLevel {
+			return log.SeverityInfo2
+		}
+		return convertLevel(l)
+	}
+	zc := NewCore(loggerName, WithLoggerProvider(rec), WithLevelSeverity(levelSeverity))
+	require.True(t, zc.Enabled(zapcore.DebugLevel))
+
+	logger := zap.New(zc)
+	logger.Debug(testMessage)
+	logger.Warn(testMessage)
+
+	var got []log.Severity
+	for _, r := range rec.Result()[logtest.Scope{Name: loggerName}] {
+		got = append(got, r.Severity)
+	}
+	require.Equal(t, []log.Severity{log.SeverityInfo2, log.SeverityWarn}, got)
+}
+
+func TestCoreSampling(t *testing.T) {
+	var dropped, sampledN int
+	hook := zapcore.SamplerHook(func(_ zapcore.Entry, dec zapcore.SamplingDecision) {
+		if dec&zapcore.LogDropped > 0 {
+			dropped++
+		} else {
+			sampledN++
+		}
+	})
+
+	p := newCaptureProvider()
+	zc := NewCore(loggerName, WithLoggerProvider(p), WithSampling(time.Hour, 2, 3, hook))
+	observed := 0
+	console := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(writerFunc(func(b []byte) (int, error) {
+			observed++
+			return len(b), nil
+		})),
+		zapcore.DebugLevel,
+	)
+	logger := zap.New(zapcore.NewTee(console, zc))
+
+	for i := range 10 {
+		// Records of the cores returned by With are sampled together.
+		logger.With(zap.Int("i", i)).Info(testMessage)
+	}
+	logger.Info("other message")
+
+	assert.Equal(t, 11, observed, "console core should not be sampled")
+	// The first 2 records, then every third: 5 and 8.
+	assert.Len(t, p.logger.records, 5)
+	assert.Equal(t, 5, sampledN)
+	assert.Equal(t, 6, dropped)
+}
+
+type writerFunc func([]byte) (int, error)
+
+func (f writerFunc) Write(b []byte) (int, error) { return f(b) }
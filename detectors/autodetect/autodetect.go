@@ -3,6 +3,11 @@
 
 // Package autodetect provides functionality to configures and use a set of
 // resource detectors at runtime.
+//
+// Use Detector to compose the detectors identified by IDs, or New to select
+// them with the OTEL_GO_RESOURCE_DETECTORS environment variable, bound the
+// detection time, and use only the first successful detector of a group,
+// such as the detectors of different clouds.
 package autodetect
 
 import (
@@ -0,0 +1,358 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autodetect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// envVarKey is the environment variable selecting the detectors used by the
+// detector returned by New.
+const envVarKey = "OTEL_GO_RESOURCE_DETECTORS"
+
+type config struct {
+	groups          [][]ID
+	timeout         time.Duration
+	detectorTimeout time.Duration
+	report          func(Report)
+}
+
+// Option configures the [resource.Detector] returned by New.
+type Option interface {
+	apply(*config)
+}
+
+type optionFunc func(*config)
+
+func (f optionFunc) apply(c *config) {
+	f(c)
+}
+
+// WithDetectors adds the detectors identified by ids. They are used if the
+// OTEL_GO_RESOURCE_DETECTORS environment variable is not set.
+func WithDetectors(ids ...ID) Option {
+	return optionFunc(func(c *config) {
+		for _, id := range ids {
+			c.groups = append(c.groups, []ID{id})
+		}
+	})
+}
+
+// WithFirstSuccess adds a group of detectors of which only the first one to
+// detect a non-empty resource without error is used. The other detectors of
+// the group are then canceled and reported as skipped. This is intended for
+// mutually exclusive detectors, such as the detectors of different clouds.
+//
+// The group is used if the OTEL_GO_RESOURCE_DETECTORS environment variable is
+// not set.
+func WithFirstSuccess(ids ...ID) Option {
+	return optionFunc(func(c *config) {
+		if len(ids) > 0 {
+			c.groups = append(c.groups, ids)
+		}
+	})
+}
+
+// WithTimeout sets the maximum duration of the whole detection. The detectors
+// not done when it expires are reported as timed out and their resources are
+// not used. By default, there is no timeout other than the one of the
+// context passed to Detect.
+func WithTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) { c.timeout = d })
+}
+
+// WithDetectorTimeout sets the maximum duration of each detector. A detector
+// not done when it expires is reported as timed out and its resource is not
+// used. By default, there is no timeout other than the one of the context
+// passed to Detect.
+func WithDetectorTimeout(d time.Duration) Option {
+	return optionFunc(func(c *config) { c.detectorTimeout = d })
+}
+
+// WithReport sets a function called with the Report of every detection.
+func WithReport(f func(Report)) Option {
+	return optionFunc(func(c *config) { c.report = f })
+}
+
+// Report describes the outcome of a detection by the detectors returned by
+// New. Each list holds the detector IDs in the configured order.
+type Report struct {
+	// Detected are the detectors that returned a resource without error.
+	Detected []ID
+	// Failed are the detectors that returned an error.
+	Failed []ID
+	// TimedOut are the detectors that did not return before their timeout
+	// or the global timeout.
+	TimedOut []ID
+	// Skipped are the detectors canceled, or whose resource was not used,
+	// because another detector of their first success group succeeded.
+	Skipped []ID
+}
+
+// New returns a [resource.Detector] composed of the detectors selected by the
+// OTEL_GO_RESOURCE_DETECTORS environment variable or, if it is not set, by
+// the WithDetectors and WithFirstSuccess options.
+//
+// OTEL_GO_RESOURCE_DETECTORS is a comma-separated list of detector IDs.
+// An element can be a "|"-separated list of IDs, forming a first success
+// group as with WithFirstSuccess. For example:
+//
+//	OTEL_GO_RESOURCE_DETECTORS=host,telemetry.sdk,aws.ec2|gcp|azure.vm
+//
+// All the detectors and groups run concurrently. Their resources are merged
+// in the configured order, the attributes of later detectors take
+// precedence.
+//
+// If an ID is not recognized, ErrUnknownDetector is returned with a detector
+// composed of the known ones.
+func New(opts ...Option) (resource.Detector, error) {
+	var cfg config
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	if env := os.Getenv(envVarKey); env != "" {
+		cfg.groups = parseGroups(env)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	d := &timedDetector{
+		timeout:         cfg.timeout,
+		detectorTimeout: cfg.detectorTimeout,
+		report:          cfg.report,
+	}
+	var err error
+	for _, ids := range cfg.groups {
+		var g group
+		for _, id := range ids {
+			fn, exists := registry[id]
+			if !exists {
+				err = errors.Join(err, fmt.Errorf("%w: %s", ErrUnknownDetector, id))
+				continue
+			}
+			g.ids = append(g.ids, id)
+			g.detectors = append(g.detectors, fn())
+		}
+		if len(g.ids) > 0 {
+			d.groups = append(d.groups, g)
+		}
+	}
+	return d, err
+}
+
+// parseGroups parses the value of the OTEL_GO_RESOURCE_DETECTORS environment
+// variable.
+func parseGroups(value string) [][]ID {
+	var groups [][]ID
+	for elem := range strings.SplitSeq(value, ",") {
+		var ids []ID
+		for id := range strings.SplitSeq(elem, "|") {
+			if id = strings.TrimSpace(id); id != "" {
+				ids = append(ids, ID(id))
+			}
+		}
+		if len(ids) > 0 {
+			groups = append(groups, ids)
+		}
+	}
+	return groups
+}
+
+// errGroupSucceeded is the cause of the cancellation of the detectors of a
+// first success group once a detector of the group succeeded.
+var errGroupSucceeded = errors.New("first success group succeeded")
+
+// group is a set of detectors of which the first successful one is used. A
+// group of a single detector always uses it.
+type group struct {
+	ids       []ID
+	detectors []resource.Detector
+}
+
+// status is the outcome of a detector.
+type status int
+
+const (
+	statusDetected status = iota
+	statusFailed
+	statusTimedOut
+	statusSkipped
+)
+
+// outcome is the outcome of a detector of a group.
+type outcome struct {
+	index  int
+	status status
+	detection
+}
+
+// timedDetector is a [resource.Detector] running groups of detectors with
+// timeouts.
+type timedDetector struct {
+	groups          []group
+	timeout         time.Duration
+	detectorTimeout time.Duration
+	report          func(Report)
+}
+
+var _ resource.Detector = (*timedDetector)(nil)
+
+// Detect runs all the groups concurrently and merges their resources in
+// order.
+func (d *timedDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if d.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.timeout)
+		defer cancel()
+	}
+
+	outcomes := make([][]outcome, len(d.groups))
+	done := make(chan struct{})
+	for i, g := range d.groups {
+		go func() {
+			outcomes[i] = d.detectGroup(ctx, g)
+			done <- struct{}{}
+		}()
+	}
+	for range d.groups {
+		<-done
+	}
+
+	var (
+		report Report
+		err    error
+	)
+	res := resource.Empty()
+	for i, g := range d.groups {
+		var groupErr error
+		succeeded := len(g.ids) > 1 && slices.ContainsFunc(outcomes[i], groupSuccess)
+		for _, o := range outcomes[i] {
+			id := g.ids[o.index]
+			switch o.status {
+			case statusDetected:
+				report.Detected = append(report.Detected, id)
+			case statusFailed:
+				report.Failed = append(report.Failed, id)
+			case statusTimedOut:
+				report.TimedOut = append(report.TimedOut, id)
+			case statusSkipped:
+				report.Skipped = append(report.Skipped, id)
+				continue
+			}
+			if o.err != nil {
+				groupErr = errors.Join(groupErr, fmt.Errorf("%s: %w", id, o.err))
+			}
+			if o.status == statusTimedOut || (succeeded && o.status == statusFailed) {
+				continue
+			}
+			var mErr error
+			if res, mErr = resource.Merge(res, o.res); mErr != nil {
+				// Merge errors are not recoverable.
+				d.doReport(report)
+				return nil, mErr
+			}
+		}
+		// The failures of the other detectors of a successful group are
+		// expected, for example off their cloud.
+		if !succeeded {
+			err = errors.Join(err, groupErr)
+		}
+	}
+	d.doReport(report)
+	return res, err
+}
+
+func (d *timedDetector) doReport(r Report) {
+	if d.report != nil {
+		d.report(r)
+	}
+}
+
+// detectGroup runs the detectors of g concurrently and returns their
+// outcomes in order. If g has more than one detector, only the first
+// detector to succeed is detected, the other ones are skipped.
+func (d *timedDetector) detectGroup(ctx context.Context, g group) []outcome {
+	first := len(g.detectors) > 1
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	results := make(chan outcome, len(g.detectors))
+	for i, det := range g.detectors {
+		go func() {
+			o := d.run(ctx, det)
+			o.index = i
+			results <- o
+		}()
+	}
+
+	outcomes := make([]outcome, len(g.detectors))
+	succeeded := false
+	for range g.detectors {
+		o := <-results
+		if first {
+			switch {
+			case succeeded:
+				// The resource of another detector is used.
+				o.status = statusSkipped
+			case groupSuccess(o):
+				succeeded = true
+				cancel(errGroupSucceeded)
+			}
+		}
+		outcomes[o.index] = o
+	}
+	return outcomes
+}
+
+// groupSuccess returns if o is the success of a first success group.
+func groupSuccess(o outcome) bool {
+	return o.status == statusDetected && o.res.Len() > 0
+}
+
+// run runs det, returning early if ctx is done or the detector timeout
+// expires.
+func (d *timedDetector) run(ctx context.Context, det resource.Detector) outcome {
+	if d.detectorTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.detectorTimeout)
+		defer cancel()
+	}
+
+	// Buffered so that a detector not returning on cancellation does not
+	// leak its goroutine once it returns.
+	done := make(chan detection, 1)
+	go func() {
+		r, e := det.Detect(ctx)
+		done <- detection{res: r, err: e}
+	}()
+
+	var o outcome
+	select {
+	case o.detection = <-done:
+		if o.err == nil {
+			o.status = statusDetected
+			return o
+		}
+	case <-ctx.Done():
+		o.err = ctx.Err()
+	}
+
+	o.status = statusFailed
+	switch {
+	case errors.Is(context.Cause(ctx), errGroupSucceeded):
+		o.status = statusSkipped
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		o.status = statusTimedOut
+	}
+	return o
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autodetect
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// blockingDetector blocks until its context is done, or forever if ignore
+// is true, like a detector probing an unreachable endpoint.
+type blockingDetector struct {
+	ignore bool
+}
+
+func (d blockingDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if d.ignore {
+		select {}
+	}
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// delayedDetector detects attr after delay.
+type delayedDetector struct {
+	delay time.Duration
+	attr  attribute.KeyValue
+}
+
+func (d delayedDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	select {
+	case <-time.After(d.delay):
+		return resource.NewSchemaless(d.attr), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func register(t *testing.T, detectors map[ID]resource.Detector) {
+	t.Helper()
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for id, d := range detectors {
+		registry[id] = func() resource.Detector { return d }
+	}
+	t.Cleanup(func() {
+		registryMu.Lock()
+		defer registryMu.Unlock()
+		for id := range detectors {
+			delete(registry, id)
+		}
+	})
+}
+
+func TestNewTimeouts(t *testing.T) {
+	a := attribute.String("a", "1")
+	register(t, map[ID]resource.Detector{
+		"test.ok":      &testDetector{attr: []attribute.KeyValue{a}},
+		"test.slow":    blockingDetector{},
+		"test.ignore":  blockingDetector{ignore: true},
+		"test.failing": &testDetector{err: errors.New("known error")},
+	})
+
+	for _, opt := range []Option{WithTimeout(50 * time.Millisecond), WithDetectorTimeout(50 * time.Millisecond)} {
+		var report Report
+		d, err := New(
+			WithDetectors("test.ok", "test.slow", "test.ignore", "test.failing"),
+			opt,
+			WithReport(func(r Report) { report = r }),
+		)
+		if err != nil {
+			t.Fatalf("got error: %v, expected no error", err)
+		}
+
+		start := time.Now()
+		res, err := d.Detect(t.Context())
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("detection took %v, expected it to time out", elapsed)
+		}
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("got error %v, expected %v", err, context.DeadlineExceeded)
+		}
+		if got := res.Attributes(); len(got) != 1 || got[0] != a {
+			t.Errorf("got %v, expected %v", got, []attribute.KeyValue{a})
+		}
+
+		want := Report{
+			Detected: []ID{"test.ok"},
+			Failed:   []ID{"test.failing"},
+			TimedOut: []ID{"test.slow", "test.ignore"},
+		}
+		if !equalReports(report, want) {
+			t.Errorf("got report %+v, expected %+v", report, want)
+		}
+	}
+}
+
+func TestNewFirstSuccess(t *testing.T) {
+	cloudA := attribute.String("cloud.provider", "a")
+	cloudB := attribute.String("cloud.provider", "b")
+	host := attribute.String("host.name", "h")
+	register(t, map[ID]resource.Detector{
+		"test.cloud.off":   &testDetector{err: errors.New("not on cloud")},
+		"test.cloud.empty": &testDetector{},
+		"test.cloud.a":     delayedDetector{delay: 10 * time.Millisecond, attr: cloudA},
+		"test.cloud.b":     delayedDetector{delay: time.Hour, attr: cloudB},
+		"test.cloud.hang":  blockingDetector{},
+		"test.host":        &testDetector{attr: []attribute.KeyValue{host}},
+	})
+
+	var report Report
+	d, err := New(
+		WithDetectors("test.host"),
+		WithFirstSuccess("test.cloud.off", "test.cloud.empty", "test.cloud.b", "test.cloud.a", "test.cloud.hang"),
+		WithReport(func(r Report) { report = r }),
+	)
+	if err != nil {
+		t.Fatalf("got error: %v, expected no error", err)
+	}
+
+	res, err := d.Detect(t.Context())
+	if err != nil {
+		t.Errorf("got error %v, expected the failures of the group to be ignored", err)
+	}
+	want := resource.NewSchemaless(host, cloudA)
+	if !res.Equal(want) {
+		t.Errorf("got %v, expected %v", res, want)
+	}
+
+	wantReport := Report{
+		Detected: []ID{"test.host", "test.cloud.empty", "test.cloud.a"},
+		Failed:   []ID{"test.cloud.off"},
+		Skipped:  []ID{"test.cloud.b", "test.cloud.hang"},
+	}
+	if !equalReports(report, wantReport) {
+		t.Errorf("got report %+v, expected %+v", report, wantReport)
+	}
+}
+
+func TestNewFirstSuccessNone(t *testing.T) {
+	knownErr := errors.New("not on cloud")
+	register(t, map[ID]resource.Detector{
+		"test.cloud.off":   &testDetector{err: knownErr},
+		"test.cloud.empty": &testDetector{},
+	})
+
+	d, err := New(WithFirstSuccess("test.cloud.off", "test.cloud.empty"))
+	if err != nil {
+		t.Fatalf("got error: %v, expected no error", err)
+	}
+	if _, err := d.Detect(t.Context()); !errors.Is(err, knownErr) {
+		t.Errorf("got error %v, expected %v", err, knownErr)
+	}
+}
+
+func TestNewEnv(t *testing.T) {
+	a := attribute.String("a", "1")
+	b := attribute.String("b", "2")
+	register(t, map[ID]resource.Detector{
+		"test.a":   &testDetector{attr: []attribute.KeyValue{a}},
+		"test.b":   &testDetector{attr: []attribute.KeyValue{b}},
+		"test.off": &testDetector{err: errors.New("not on cloud")},
+	})
+	t.Setenv(envVarKey, "test.a, test.off|test.b,unknown")
+
+	var report Report
+	d, err := New(WithDetectors("test.off"), WithReport(func(r Report) { report = r }))
+	if !errors.Is(err, ErrUnknownDetector) {
+		t.Errorf("got error %v, expected %v", err, ErrUnknownDetector)
+	}
+
+	res, err := d.Detect(t.Context())
+	if err != nil {
+		t.Errorf("got error: %v, expected no error", err)
+	}
+	if want := resource.NewSchemaless(a, b); !res.Equal(want) {
+		t.Errorf("got %v, expected %v", res, want)
+	}
+	if want := []ID{"test.a", "test.b"}; !slices.Equal(report.Detected, want) {
+		t.Errorf("got detected %v, expected %v", report.Detected, want)
+	}
+}
+
+func TestParseGroups(t *testing.T) {
+	got := parseGroups(" host ,aws.ec2|gcp| azure.vm,,|")
+	want := [][]ID{{"host"}, {"aws.ec2", "gcp", "azure.vm"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("got %v, expected %v", got, want)
+	}
+}
+
+func equalReports(a, b Report) bool {
+	return slices.Equal(a.Detected, b.Detected) &&
+		slices.Equal(a.Failed, b.Failed) &&
+		slices.Equal(a.TimedOut, b.TimedOut) &&
+		slices.Equal(a.Skipped, b.Skipped)
+}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autodetect_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/sdk/resource"
+
+	"go.opentelemetry.io/contrib/detectors/autodetect"
+)
+
+func init() {
+	id := autodetect.ID("my.new.detector")
+	autodetect.Register(id, func() resource.Detector {
+		return MyDetector{}
+	})
+}
+
+func ExampleNew() {
+	// This example shows how to bound the detection time and to only use the
+	// first detector of a group that succeeds. The OTEL_GO_RESOURCE_DETECTORS
+	// environment variable, if set, overrides the detectors, for example
+	// with "host,telemetry.sdk,my.new.detector|aws.ec2|gcp".
+
+	detector, err := autodetect.New(
+		autodetect.WithDetectors("host", "telemetry.sdk"),
+		autodetect.WithFirstSuccess("my.new.detector", "aws.ec2", "gcp"),
+		autodetect.WithTimeout(5*time.Second),
+		autodetect.WithDetectorTimeout(2*time.Second),
+		autodetect.WithReport(func(r autodetect.Report) {
+			// Log the detectors that timed out or were skipped as needed.
+			_ = r.TimedOut
+		}),
+	)
+	if err != nil {
+		panic(err)
+	}
+
+	res, err := detector.Detect(context.Background())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Print(enc.Encode(res.Iter()))
+	// Output:
+	//   host.name my.key telemetry.sdk.language telemetry.sdk.name telemetry.sdk.version
+}
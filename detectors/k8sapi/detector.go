@@ -21,9 +21,13 @@ import (
 const defaultNodeEnvVar = "K8S_NODE_NAME"
 
 type config struct {
-	nodeEnvVar string
-	kubeClient kubernetes.Interface
-	filter     attribute.Filter
+	nodeEnvVar      string
+	podEnvVar       string
+	namespaceEnvVar string
+	podLabels       []string
+	podAnnotations  []string
+	kubeClient      kubernetes.Interface
+	filter          attribute.Filter
 }
 
 // Option configures a [ResourceDetector].
@@ -43,7 +47,33 @@ func WithNodeEnvVar(name string) Option {
 	return optionFunc(func(c *config) { c.nodeEnvVar = name })
 }
 
-// WithKubeClient sets the Kubernetes client used to query the node and the kube-system namespace. If not
+// WithPodEnvVar sets the environment variable name from which the Kubernetes
+// pod name is read. Defaults to "K8S_POD_NAME".
+func WithPodEnvVar(name string) Option {
+	return optionFunc(func(c *config) { c.podEnvVar = name })
+}
+
+// WithNamespaceEnvVar sets the environment variable name from which the
+// Kubernetes namespace name of the pod is read. Defaults to
+// "K8S_NAMESPACE_NAME".
+func WithNamespaceEnvVar(name string) Option {
+	return optionFunc(func(c *config) { c.namespaceEnvVar = name })
+}
+
+// WithPodLabels sets the keys of the pod labels added as k8s.pod.label.<key>
+// attributes. By default no label is added.
+func WithPodLabels(keys ...string) Option {
+	return optionFunc(func(c *config) { c.podLabels = keys })
+}
+
+// WithPodAnnotations sets the keys of the pod annotations added as
+// k8s.pod.annotation.<key> attributes. By default no annotation is added.
+func WithPodAnnotations(keys ...string) Option {
+	return optionFunc(func(c *config) { c.podAnnotations = keys })
+}
+
+// WithKubeClient sets the Kubernetes client used to query the node, the pod
+// and its owners, and the kube-system namespace. If not
 // set, an in-cluster client is created automatically during
 // [ResourceDetector.Detect]. This option is primarily useful for testing or
 // when running outside a cluster.
@@ -58,8 +88,8 @@ func WithAttributeFilter(filter attribute.Filter) Option {
 	return optionFunc(func(c *config) { c.filter = filter })
 }
 
-// ResourceDetector collects resource attributes from the Kubernetes node the
-// process is running on.
+// ResourceDetector collects resource attributes from the Kubernetes node and
+// pod the process is running on.
 type ResourceDetector struct {
 	cfg             config
 	createProvider  func(*rest.Config) (kubernetes.Interface, error)
@@ -69,9 +99,11 @@ type ResourceDetector struct {
 // Compile-time interface assertion.
 var _ resource.Detector = (*ResourceDetector)(nil)
 
-// Detect returns a [*resource.Resource] describing the Kubernetes node and
-// cluster. If the node-name environment variable is not set, node attributes
-// are omitted but k8s.cluster.uid may still be detected. If the process is
+// Detect returns a [*resource.Resource] describing the Kubernetes node, pod
+// and cluster. If the node-name environment variable is not set, node
+// attributes are omitted, and if the pod-name or namespace environment
+// variables are not set, pod attributes are omitted, but k8s.cluster.uid may
+// still be detected. If the process is
 // not running inside a cluster, an empty resource and no error are returned.
 func (rd *ResourceDetector) Detect(ctx context.Context) (*resource.Resource, error) {
 	client := rd.cfg.kubeClient
@@ -105,6 +137,12 @@ func (rd *ResourceDetector) Detect(ctx context.Context) (*resource.Resource, err
 		}
 	}
 
+	podAttrs, err := rd.detectPod(ctx, client)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	attrs = append(attrs, podAttrs...)
+
 	ns, err := client.CoreV1().Namespaces().Get(ctx, "kube-system", metav1.GetOptions{})
 	if err != nil {
 		errs = append(errs, fmt.Errorf("kube-system namespace: %w", err))
@@ -133,7 +171,7 @@ func (rd *ResourceDetector) Detect(ctx context.Context) (*resource.Resource, err
 }
 
 // NewResourceDetector returns a [resource.Detector] that detects resource
-// attributes on the Kubernetes node the process is running on.
+// attributes on the Kubernetes node and pod the process is running on.
 //
 // The node name is read from the K8S_NODE_NAME environment variable by
 // default. Use [WithNodeEnvVar] to customize the variable name. The variable
@@ -144,8 +182,17 @@ func (rd *ResourceDetector) Detect(ctx context.Context) (*resource.Resource, err
 //	    valueFrom:
 //	      fieldRef:
 //	        fieldPath: spec.nodeName
+//
+// The pod and namespace names are read from the K8S_POD_NAME and
+// K8S_NAMESPACE_NAME environment variables by default, populated with the
+// metadata.name and metadata.namespace fields. Use [WithPodEnvVar] and
+// [WithNamespaceEnvVar] to customize the variable names.
 func NewResourceDetector(opts ...Option) *ResourceDetector {
-	cfg := config{nodeEnvVar: defaultNodeEnvVar}
+	cfg := config{
+		nodeEnvVar:      defaultNodeEnvVar,
+		podEnvVar:       defaultPodEnvVar,
+		namespaceEnvVar: defaultNamespaceEnvVar,
+	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
@@ -11,6 +11,17 @@ each of the following attributes is added if it is available:
   - k8s.node.name
   - k8s.node.uid
   - k8s.cluster.uid
+  - k8s.namespace.name
+  - k8s.pod.name
+  - k8s.pod.uid
+  - k8s.pod.label.<key> and k8s.pod.annotation.<key>, see [WithPodLabels]
+    and [WithPodAnnotations]
+  - k8s.replicaset.name and k8s.replicaset.uid
+  - k8s.deployment.name and k8s.deployment.uid
+  - k8s.statefulset.name and k8s.statefulset.uid
+  - k8s.daemonset.name and k8s.daemonset.uid
+  - k8s.job.name and k8s.job.uid
+  - k8s.cronjob.name and k8s.cronjob.uid
 
 Node attributes require the K8S_NODE_NAME environment variable to be set,
 typically via the Kubernetes downward API:
@@ -27,6 +38,33 @@ Node attributes (k8s.node.name, k8s.node.uid) require the following RBAC:
     resources: ["nodes"]
     verbs: ["get"]
 
+Pod attributes require the K8S_POD_NAME and K8S_NAMESPACE_NAME environment
+variables to be set, also via the downward API:
+
+	env:
+	  - name: K8S_POD_NAME
+	    valueFrom:
+	      fieldRef:
+	        fieldPath: metadata.name
+	  - name: K8S_NAMESPACE_NAME
+	    valueFrom:
+	      fieldRef:
+	        fieldPath: metadata.namespace
+
+The workload attributes are resolved from the controller owner of the pod.
+The Deployment of a ReplicaSet and the CronJob of a Job are read from the API.
+Pod and workload attributes require the following RBAC:
+
+  - apiGroups: [""]
+    resources: ["pods"]
+    verbs: ["get"]
+  - apiGroups: ["apps"]
+    resources: ["replicasets"]
+    verbs: ["get"]
+  - apiGroups: ["batch"]
+    resources: ["jobs"]
+    verbs: ["get"]
+
 The cluster UID is derived from the kube-system namespace UID and requires
 the following RBAC:
 
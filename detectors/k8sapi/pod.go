@@ -0,0 +1,97 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sapi
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultPodEnvVar       = "K8S_POD_NAME"
+	defaultNamespaceEnvVar = "K8S_NAMESPACE_NAME"
+)
+
+// detectPod returns the attributes of the pod named by the pod environment
+// variable in the namespace named by the namespace environment variable.
+func (rd *ResourceDetector) detectPod(ctx context.Context, client kubernetes.Interface) ([]attribute.KeyValue, error) {
+	namespace := os.Getenv(rd.cfg.namespaceEnvVar)
+	if namespace == "" {
+		return nil, nil
+	}
+	attrs := []attribute.KeyValue{semconv.K8SNamespaceName(namespace)}
+
+	podName := os.Getenv(rd.cfg.podEnvVar)
+	if podName == "" {
+		return attrs, nil
+	}
+	pod, err := client.CoreV1().Pods(namespace).Get(ctx, podName, metav1.GetOptions{})
+	if err != nil {
+		return attrs, fmt.Errorf("pod %q: %w", podName, err)
+	}
+	attrs = append(attrs, semconv.K8SPodName(pod.Name), semconv.K8SPodUID(string(pod.UID)))
+
+	for _, key := range rd.cfg.podLabels {
+		if v, ok := pod.Labels[key]; ok {
+			attrs = append(attrs, semconv.K8SPodLabel(key, v))
+		}
+	}
+	for _, key := range rd.cfg.podAnnotations {
+		if v, ok := pod.Annotations[key]; ok {
+			attrs = append(attrs, semconv.K8SPodAnnotation(key, v))
+		}
+	}
+
+	owners, err := detectOwners(ctx, client, pod)
+	return append(attrs, owners...), err
+}
+
+// detectOwners returns the attributes of the workloads controlling pod. The
+// Deployment of a ReplicaSet and the CronJob of a Job are resolved with the
+// API.
+func detectOwners(ctx context.Context, client kubernetes.Interface, pod *corev1.Pod) ([]attribute.KeyValue, error) {
+	owner := metav1.GetControllerOf(pod)
+	if owner == nil {
+		return nil, nil
+	}
+
+	var (
+		attrs []attribute.KeyValue
+		err   error
+	)
+	switch owner.Kind {
+	case "ReplicaSet":
+		attrs = append(attrs, semconv.K8SReplicaSetName(owner.Name), semconv.K8SReplicaSetUID(string(owner.UID)))
+		rs, e := client.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if e != nil {
+			err = fmt.Errorf("replicaset %q: %w", owner.Name, e)
+			break
+		}
+		if d := metav1.GetControllerOf(rs); d != nil && d.Kind == "Deployment" {
+			attrs = append(attrs, semconv.K8SDeploymentName(d.Name), semconv.K8SDeploymentUID(string(d.UID)))
+		}
+	case "StatefulSet":
+		attrs = append(attrs, semconv.K8SStatefulSetName(owner.Name), semconv.K8SStatefulSetUID(string(owner.UID)))
+	case "DaemonSet":
+		attrs = append(attrs, semconv.K8SDaemonSetName(owner.Name), semconv.K8SDaemonSetUID(string(owner.UID)))
+	case "Job":
+		attrs = append(attrs, semconv.K8SJobName(owner.Name), semconv.K8SJobUID(string(owner.UID)))
+		job, e := client.BatchV1().Jobs(pod.Namespace).Get(ctx, owner.Name, metav1.GetOptions{})
+		if e != nil {
+			err = fmt.Errorf("job %q: %w", owner.Name, e)
+			break
+		}
+		if cj := metav1.GetControllerOf(job); cj != nil && cj.Kind == "CronJob" {
+			attrs = append(attrs, semconv.K8SCronJobName(cj.Name), semconv.K8SCronJobUID(string(cj.UID)))
+		}
+	}
+	return attrs, err
+}
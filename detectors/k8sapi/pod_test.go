@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package k8sapi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+const (
+	testPodName   = "my-pod"
+	testNamespace = "my-namespace"
+)
+
+func controllerRef(kind, name string, uid types.UID) []metav1.OwnerReference {
+	controller := true
+	return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid, Controller: &controller}}
+}
+
+func newFakePod(uid types.UID, owners []metav1.OwnerReference) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            testPodName,
+			Namespace:       testNamespace,
+			UID:             uid,
+			OwnerReferences: owners,
+			Labels:          map[string]string{"app": "checkout", "tier": "web"},
+			Annotations:     map[string]string{"team": "payments"},
+		},
+	}
+}
+
+func setPodEnv(t *testing.T) {
+	t.Helper()
+	t.Setenv("K8S_NODE_NAME", "")
+	t.Setenv("K8S_POD_NAME", testPodName)
+	t.Setenv("K8S_NAMESPACE_NAME", testNamespace)
+}
+
+// podFilter excludes the cluster UID of the pod tests.
+var podFilter = attribute.NewDenyKeysFilter(semconv.K8SClusterUIDKey)
+
+func TestDetectPodOwners(t *testing.T) {
+	podUID := uuid.NewUUID()
+	ownerUID := uuid.NewUUID()
+	parentUID := uuid.NewUUID()
+
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    []attribute.KeyValue
+	}{
+		{
+			name: "no owner",
+			objects: []runtime.Object{
+				newFakePod(podUID, nil),
+			},
+		},
+		{
+			name: "deployment",
+			objects: []runtime.Object{
+				newFakePod(podUID, controllerRef("ReplicaSet", "web-5d4f", ownerUID)),
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name:            "web-5d4f",
+					Namespace:       testNamespace,
+					UID:             ownerUID,
+					OwnerReferences: controllerRef("Deployment", "web", parentUID),
+				}},
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SReplicaSetName("web-5d4f"),
+				semconv.K8SReplicaSetUID(string(ownerUID)),
+				semconv.K8SDeploymentName("web"),
+				semconv.K8SDeploymentUID(string(parentUID)),
+			},
+		},
+		{
+			name: "replicaset",
+			objects: []runtime.Object{
+				newFakePod(podUID, controllerRef("ReplicaSet", "web-5d4f", ownerUID)),
+				&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+					Name:      "web-5d4f",
+					Namespace: testNamespace,
+					UID:       ownerUID,
+				}},
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SReplicaSetName("web-5d4f"),
+				semconv.K8SReplicaSetUID(string(ownerUID)),
+			},
+		},
+		{
+			name: "statefulset",
+			objects: []runtime.Object{
+				newFakePod(podUID, controllerRef("StatefulSet", "db", ownerUID)),
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SStatefulSetName("db"),
+				semconv.K8SStatefulSetUID(string(ownerUID)),
+			},
+		},
+		{
+			name: "daemonset",
+			objects: []runtime.Object{
+				newFakePod(podUID, controllerRef("DaemonSet", "agent", ownerUID)),
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SDaemonSetName("agent"),
+				semconv.K8SDaemonSetUID(string(ownerUID)),
+			},
+		},
+		{
+			name: "cronjob",
+			objects: []runtime.Object{
+				newFakePod(podUID, controllerRef("Job", "report-28000000", ownerUID)),
+				&batchv1.Job{ObjectMeta: metav1.ObjectMeta{
+					Name:            "report-28000000",
+					Namespace:       testNamespace,
+					UID:             ownerUID,
+					OwnerReferences: controllerRef("CronJob", "report", parentUID),
+				}},
+			},
+			want: []attribute.KeyValue{
+				semconv.K8SJobName("report-28000000"),
+				semconv.K8SJobUID(string(ownerUID)),
+				semconv.K8SCronJobName("report"),
+				semconv.K8SCronJobUID(string(parentUID)),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setPodEnv(t)
+			client := k8sfake.NewClientset(append(tt.objects, newFakeNamespace(uuid.NewUUID()))...)
+
+			res, err := NewResourceDetector(WithKubeClient(client), WithAttributeFilter(podFilter)).Detect(t.Context())
+			require.NoError(t, err)
+
+			want := append([]attribute.KeyValue{
+				semconv.K8SNamespaceName(testNamespace),
+				semconv.K8SPodName(testPodName),
+				semconv.K8SPodUID(string(podUID)),
+			}, tt.want...)
+			assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, want...), res)
+		})
+	}
+}
+
+func TestDetectPodLabelsAndAnnotations(t *testing.T) {
+	setPodEnv(t)
+	podUID := uuid.NewUUID()
+	client := k8sfake.NewClientset(newFakePod(podUID, nil))
+
+	res, err := NewResourceDetector(
+		WithKubeClient(client),
+		WithAttributeFilter(podFilter),
+		WithPodLabels("app", "missing"),
+		WithPodAnnotations("team"),
+	).Detect(t.Context())
+	require.ErrorIs(t, err, resource.ErrPartialResource, "kube-system namespace is missing")
+
+	expected := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.K8SNamespaceName(testNamespace),
+		semconv.K8SPodName(testPodName),
+		semconv.K8SPodUID(string(podUID)),
+		semconv.K8SPodLabel("app", "checkout"),
+		semconv.K8SPodAnnotation("team", "payments"),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectPodEnvVars(t *testing.T) {
+	t.Setenv("K8S_NODE_NAME", "")
+	t.Setenv("MY_POD", testPodName)
+	t.Setenv("MY_NAMESPACE", testNamespace)
+	podUID := uuid.NewUUID()
+	client := k8sfake.NewClientset(newFakePod(podUID, nil), newFakeNamespace(uuid.NewUUID()))
+
+	res, err := NewResourceDetector(
+		WithKubeClient(client),
+		WithAttributeFilter(podFilter),
+		WithPodEnvVar("MY_POD"),
+		WithNamespaceEnvVar("MY_NAMESPACE"),
+	).Detect(t.Context())
+	require.NoError(t, err)
+
+	expected := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.K8SNamespaceName(testNamespace),
+		semconv.K8SPodName(testPodName),
+		semconv.K8SPodUID(string(podUID)),
+	)
+	assert.Equal(t, expected, res)
+}
+
+func TestDetectNamespaceOnly(t *testing.T) {
+	setPodEnv(t)
+	t.Setenv("K8S_POD_NAME", "")
+	client := k8sfake.NewClientset(newFakeNamespace(uuid.NewUUID()))
+
+	res, err := NewResourceDetector(WithKubeClient(client), WithAttributeFilter(podFilter)).Detect(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, semconv.K8SNamespaceName(testNamespace)), res)
+}
+
+func TestDetectPodError(t *testing.T) {
+	setPodEnv(t)
+	client := k8sfake.NewClientset(newFakeNamespace(uuid.NewUUID()))
+
+	res, err := NewResourceDetector(WithKubeClient(client), WithAttributeFilter(podFilter)).Detect(t.Context())
+	require.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorContains(t, err, `pod "my-pod"`)
+	assert.Equal(t, resource.NewWithAttributes(semconv.SchemaURL, semconv.K8SNamespaceName(testNamespace)), res)
+}
+
+func TestDetectPodOwnerError(t *testing.T) {
+	setPodEnv(t)
+	podUID := uuid.NewUUID()
+	rsUID := uuid.NewUUID()
+	// The ReplicaSet is missing, for example for a missing RBAC rule.
+	client := k8sfake.NewClientset(
+		newFakePod(podUID, controllerRef("ReplicaSet", "web-5d4f", rsUID)),
+		newFakeNamespace(uuid.NewUUID()),
+	)
+
+	res, err := NewResourceDetector(WithKubeClient(client), WithAttributeFilter(podFilter)).Detect(t.Context())
+	require.ErrorIs(t, err, resource.ErrPartialResource)
+	assert.ErrorContains(t, err, `replicaset "web-5d4f"`)
+
+	expected := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.K8SNamespaceName(testNamespace),
+		semconv.K8SPodName(testPodName),
+		semconv.K8SPodUID(string(podUID)),
+		semconv.K8SReplicaSetName("web-5d4f"),
+		semconv.K8SReplicaSetUID(string(rsUID)),
+	)
+	assert.Equal(t, expected, res)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -69,6 +70,12 @@ type config struct {
 	// returned by otel.GetTracerProvider()
 	TracerProvider trace.TracerProvider
 
+	// MeterProvider is the MeterProvider which will be used
+	// to create instrumentation metrics
+	// The default value of MeterProvider the global otel MeterProvider
+	// returned by otel.GetMeterProvider()
+	MeterProvider metric.MeterProvider
+
 	// Flusher is the mechanism used to flush any unexported spans
 	// each Lambda Invocation to avoid spans being unexported for long
 	// when periods of time if Lambda freezes the execution environment
@@ -82,8 +89,15 @@ type config struct {
 	// The default value of eventToCarrier is emptyEventToCarrier which returns
 	// an empty HeaderCarrier, using this default will cause new spans to be part
 	// of a new Trace and have no parent past our Lambda instrumentation span
+	// If EventSourceDetection is enabled, the default value is
+	// EventSourceEventToCarrier instead
 	EventToCarrier EventToCarrier
 
+	// EventSourceDetection enables the detection of the event source of
+	// each invocation from its event to shape the instrumentation span
+	// The default value of EventSourceDetection is false
+	EventSourceDetection bool
+
 	// Propagator is the Propagator which will be used
 	// to extract Trace info into the context
 	// The default value of Propagator the global otel Propagator
@@ -107,6 +121,19 @@ func WithTracerProvider(tracerProvider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider configures the MeterProvider used by the
+// instrumentation to record the faas.invoke_duration, faas.errors and
+// faas.coldstarts metrics. If the MeterProvider has a
+// ForceFlush(context.Context) error method, like the SDK MeterProvider, it
+// is called at the end of each invocation.
+//
+// By default, the global MeterProvider is used.
+func WithMeterProvider(meterProvider metric.MeterProvider) Option {
+	return optionFunc(func(c *config) {
+		c.MeterProvider = meterProvider
+	})
+}
+
 // WithFlusher sets the used flusher.
 func WithFlusher(flusher Flusher) Option {
 	return optionFunc(func(c *config) {
@@ -136,3 +163,24 @@ func WithTraceAttributeFn(fn TraceAttributeFn) Option {
 		c.TraceAttributeFn = fn
 	})
 }
+
+// WithEventSourceDetection enables the detection of the AWS service that
+// triggered each invocation from its event. API Gateway REST and HTTP API,
+// Lambda function URL, Application Load Balancer, SQS, SNS, Kinesis, S3 and
+// EventBridge events are detected. The span of the invocation then has:
+//   - the faas.trigger attribute,
+//   - the consumer span kind for messaging events, and the server span kind
+//     otherwise,
+//   - attributes describing the request or the messages of the event,
+//   - a link to the trace context propagated in the message attributes of
+//     every record of SQS events.
+//
+// The faas.trigger attribute is also added to the metrics.
+//
+// Unless an EventToCarrier is configured, [EventSourceEventToCarrier] is
+// used to extract the parent trace context from the event.
+func WithEventSourceDetection() Option {
+	return optionFunc(func(c *config) {
+		c.EventSourceDetection = true
+	})
+}
@@ -0,0 +1,283 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otellambda
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// lambdaEvent holds the fields of the events of the supported event sources
+// used to identify them. Fields of events of different sources are merged.
+type lambdaEvent struct {
+	// API Gateway REST API (v1), HTTP API (v2) and ALB events.
+	Version           string              `json:"version"`
+	HTTPMethod        string              `json:"httpMethod"`
+	Resource          string              `json:"resource"`
+	Path              string              `json:"path"`
+	RouteKey          string              `json:"routeKey"`
+	RawPath           string              `json:"rawPath"`
+	Headers           map[string]string   `json:"headers"`
+	MultiValueHeaders map[string][]string `json:"multiValueHeaders"`
+	RequestContext    struct {
+		ELB *struct {
+			TargetGroupArn string `json:"targetGroupArn"`
+		} `json:"elb"`
+		HTTP *struct {
+			Method string `json:"method"`
+		} `json:"http"`
+	} `json:"requestContext"`
+
+	// SQS, SNS, Kinesis and S3 events.
+	Records []lambdaEventRecord `json:"Records"`
+
+	// EventBridge events.
+	Source     string `json:"source"`
+	DetailType string `json:"detail-type"`
+}
+
+type lambdaEventRecord struct {
+	// EventSource is used by SQS, Kinesis and S3 records, SNS records use
+	// SNSEventSource.
+	EventSource    string `json:"eventSource"`
+	SNSEventSource string `json:"EventSource"`
+	EventSourceARN string `json:"eventSourceARN"`
+
+	MessageID         string `json:"messageId"`
+	MessageAttributes map[string]struct {
+		StringValue *string `json:"stringValue"`
+	} `json:"messageAttributes"`
+
+	SNS *struct {
+		MessageID         string `json:"MessageId"`
+		TopicArn          string `json:"TopicArn"`
+		MessageAttributes map[string]struct {
+			Type  string `json:"Type"`
+			Value string `json:"Value"`
+		} `json:"MessageAttributes"`
+	} `json:"Sns"`
+
+	S3 *struct {
+		Bucket struct {
+			Name string `json:"name"`
+		} `json:"bucket"`
+		Object struct {
+			Key string `json:"key"`
+		} `json:"object"`
+	} `json:"s3"`
+}
+
+// eventSource describes how an invocation triggered by an event source is
+// represented.
+type eventSource struct {
+	trigger attribute.KeyValue
+	kind    trace.SpanKind
+	// carrier holds the trace context of the event, or is nil if the event
+	// source does not propagate one to the span of the invocation.
+	carrier propagation.TextMapCarrier
+	// records holds the carriers of the records of the event the span of the
+	// invocation is linked to.
+	records []recordCarrier
+	attrs   []attribute.KeyValue
+}
+
+type recordCarrier struct {
+	messageID string
+	carrier   propagation.TextMapCarrier
+}
+
+var otherEventSource = eventSource{
+	trigger: semconv.FaaSTriggerOther,
+	kind:    trace.SpanKindServer,
+}
+
+// detectEventSource identifies the AWS service that produced the event.
+func detectEventSource(eventJSON []byte) eventSource {
+	var evt lambdaEvent
+	if len(eventJSON) == 0 || json.Unmarshal(eventJSON, &evt) != nil {
+		return otherEventSource
+	}
+
+	switch {
+	case evt.RequestContext.ELB != nil:
+		return httpEventSource(evt, evt.HTTPMethod, evt.Path, "")
+	case evt.Version == "2.0" && evt.RequestContext.HTTP != nil:
+		route := ""
+		if _, r, ok := strings.Cut(evt.RouteKey, " "); ok {
+			route = r
+		}
+		return httpEventSource(evt, evt.RequestContext.HTTP.Method, evt.RawPath, route)
+	case evt.HTTPMethod != "":
+		return httpEventSource(evt, evt.HTTPMethod, evt.Path, evt.Resource)
+	case len(evt.Records) > 0:
+		return recordsEventSource(evt.Records)
+	case evt.Source == "aws.events" && evt.DetailType == "Scheduled Event":
+		return eventSource{trigger: semconv.FaaSTriggerTimer, kind: trace.SpanKindServer}
+	case evt.Source != "" && evt.DetailType != "":
+		return eventSource{
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemKey.String("aws_eventbridge"),
+				semconv.MessagingOperationTypeProcess,
+			},
+		}
+	}
+	return otherEventSource
+}
+
+func httpEventSource(evt lambdaEvent, method, path, route string) eventSource {
+	h := make(http.Header, len(evt.Headers)+len(evt.MultiValueHeaders))
+	for k, vals := range evt.MultiValueHeaders {
+		for _, v := range vals {
+			h.Add(k, v)
+		}
+	}
+	for k, v := range evt.Headers {
+		if h.Get(k) == "" {
+			h.Set(k, v)
+		}
+	}
+
+	attrs := []attribute.KeyValue{semconv.HTTPRequestMethodKey.String(method)}
+	if path != "" {
+		attrs = append(attrs, semconv.URLPath(path))
+	}
+	if route != "" {
+		attrs = append(attrs, semconv.HTTPRoute(route))
+	}
+	return eventSource{
+		trigger: semconv.FaaSTriggerHTTP,
+		kind:    trace.SpanKindServer,
+		carrier: propagation.HeaderCarrier(h),
+		attrs:   attrs,
+	}
+}
+
+func recordsEventSource(records []lambdaEventRecord) eventSource {
+	first := records[0]
+	switch {
+	case first.EventSource == "aws:sqs":
+		src := eventSource{
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemAWSSQS,
+				semconv.MessagingOperationTypeProcess,
+				semconv.MessagingDestinationName(arnResource(first.EventSourceARN)),
+			},
+		}
+		if len(records) > 1 {
+			src.attrs = append(src.attrs, semconv.MessagingBatchMessageCount(len(records)))
+		}
+		for _, r := range records {
+			c := propagation.MapCarrier{}
+			for k, v := range r.MessageAttributes {
+				if v.StringValue != nil {
+					c[k] = *v.StringValue
+				}
+			}
+			src.records = append(src.records, recordCarrier{messageID: r.MessageID, carrier: c})
+		}
+		return src
+	case first.SNSEventSource == "aws:sns" && first.SNS != nil:
+		c := propagation.MapCarrier{}
+		for k, v := range first.SNS.MessageAttributes {
+			if v.Type == "String" {
+				c[k] = v.Value
+			}
+		}
+		return eventSource{
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			carrier: c,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemKey.String("aws_sns"),
+				semconv.MessagingOperationTypeProcess,
+				semconv.MessagingDestinationName(arnResource(first.SNS.TopicArn)),
+				semconv.MessagingMessageID(first.SNS.MessageID),
+			},
+		}
+	case first.EventSource == "aws:kinesis":
+		src := eventSource{
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemKey.String("aws_kinesis"),
+				semconv.MessagingOperationTypeProcess,
+			},
+		}
+		if _, name, ok := strings.Cut(first.EventSourceARN, ":stream/"); ok {
+			src.attrs = append(src.attrs, semconv.AWSKinesisStreamName(name))
+		}
+		if len(records) > 1 {
+			src.attrs = append(src.attrs, semconv.MessagingBatchMessageCount(len(records)))
+		}
+		return src
+	case first.EventSource == "aws:s3" && first.S3 != nil:
+		src := eventSource{
+			trigger: semconv.FaaSTriggerDatasource,
+			kind:    trace.SpanKindConsumer,
+			attrs:   []attribute.KeyValue{semconv.AWSS3Bucket(first.S3.Bucket.Name)},
+		}
+		if len(records) == 1 {
+			src.attrs = append(src.attrs, semconv.AWSS3Key(first.S3.Object.Key))
+		}
+		return src
+	}
+	return otherEventSource
+}
+
+// arnResource returns the last colon separated part of an ARN, the name of
+// SQS queues and SNS topics.
+func arnResource(arn string) string {
+	return arn[strings.LastIndex(arn, ":")+1:]
+}
+
+// links returns a link to the trace context of every record of the event
+// carrying a valid one.
+func (s eventSource) links(ctx context.Context, p propagation.TextMapPropagator) []trace.Link {
+	var links []trace.Link
+	for _, r := range s.records {
+		// Extract into a context without a span, so the span of ctx is not
+		// returned for records without a trace context.
+		rctx := p.Extract(trace.ContextWithSpanContext(ctx, trace.SpanContext{}), r.carrier)
+		sc := trace.SpanContextFromContext(rctx)
+		if !sc.IsValid() {
+			continue
+		}
+		var attrs []attribute.KeyValue
+		if r.messageID != "" {
+			attrs = append(attrs, semconv.MessagingMessageID(r.messageID))
+		}
+		links = append(links, trace.Link{SpanContext: sc, Attributes: attrs})
+	}
+	return links
+}
+
+// EventSourceEventToCarrier is an EventToCarrier that returns the trace
+// context propagated by the event source of the invocation:
+//   - the HTTP headers of API Gateway REST and HTTP API, Lambda function URL
+//     and Application Load Balancer events,
+//   - the String message attributes of SNS events.
+//
+// An empty carrier is returned for other events. The trace context of the
+// records of SQS events are instead used to link the invocation span to the
+// messages, see [WithEventSourceDetection].
+func EventSourceEventToCarrier(eventJSON []byte) propagation.TextMapCarrier {
+	if c := detectEventSource(eventJSON).carrier; c != nil {
+		return c
+	}
+	return emptyEventToCarrier(eventJSON)
+}
+
+// Compile time check EventSourceEventToCarrier implements EventToCarrier.
+var _ EventToCarrier = EventSourceEventToCarrier
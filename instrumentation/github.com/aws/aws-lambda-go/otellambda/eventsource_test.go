@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otellambda
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testTraceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func TestDetectEventSource(t *testing.T) {
+	testCases := []struct {
+		name    string
+		event   string
+		trigger attribute.KeyValue
+		kind    trace.SpanKind
+		attrs   []attribute.KeyValue
+		carrier propagation.TextMapCarrier
+		records int
+	}{
+		{
+			name:    "empty",
+			event:   ``,
+			trigger: semconv.FaaSTriggerOther,
+			kind:    trace.SpanKindServer,
+		},
+		{
+			name:    "unknown",
+			event:   `{"name":"Lambda"}`,
+			trigger: semconv.FaaSTriggerOther,
+			kind:    trace.SpanKindServer,
+		},
+		{
+			name: "API Gateway REST API",
+			event: `{"resource":"/users/{id}","path":"/users/1","httpMethod":"GET",` +
+				`"headers":{"traceparent":"` + testTraceparent + `"},"requestContext":{"stage":"prod"}}`,
+			trigger: semconv.FaaSTriggerHTTP,
+			kind:    trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String("GET"),
+				semconv.URLPath("/users/1"),
+				semconv.HTTPRoute("/users/{id}"),
+			},
+			carrier: propagation.HeaderCarrier{"Traceparent": []string{testTraceparent}},
+		},
+		{
+			name: "API Gateway HTTP API",
+			event: `{"version":"2.0","routeKey":"POST /users","rawPath":"/users",` +
+				`"headers":{"traceparent":"` + testTraceparent + `"},"requestContext":{"http":{"method":"POST"}}}`,
+			trigger: semconv.FaaSTriggerHTTP,
+			kind:    trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String("POST"),
+				semconv.URLPath("/users"),
+				semconv.HTTPRoute("/users"),
+			},
+			carrier: propagation.HeaderCarrier{"Traceparent": []string{testTraceparent}},
+		},
+		{
+			name: "ALB",
+			event: `{"httpMethod":"GET","path":"/health","multiValueHeaders":{"traceparent":["` + testTraceparent + `"]},` +
+				`"requestContext":{"elb":{"targetGroupArn":"arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/tg/1"}}}`,
+			trigger: semconv.FaaSTriggerHTTP,
+			kind:    trace.SpanKindServer,
+			attrs: []attribute.KeyValue{
+				semconv.HTTPRequestMethodKey.String("GET"),
+				semconv.URLPath("/health"),
+			},
+			carrier: propagation.HeaderCarrier{"Traceparent": []string{testTraceparent}},
+		},
+		{
+			name: "SQS",
+			event: `{"Records":[` +
+				`{"messageId":"1","eventSource":"aws:sqs","eventSourceARN":"arn:aws:sqs:us-east-1:123456789012:orders",` +
+				`"messageAttributes":{"traceparent":{"stringValue":"` + testTraceparent + `","dataType":"String"}}},` +
+				`{"messageId":"2","eventSource":"aws:sqs","eventSourceARN":"arn:aws:sqs:us-east-1:123456789012:orders"}]}`,
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemAWSSQS,
+				semconv.MessagingOperationTypeProcess,
+				semconv.MessagingDestinationName("orders"),
+				semconv.MessagingBatchMessageCount(2),
+			},
+			records: 2,
+		},
+		{
+			name: "SNS",
+			event: `{"Records":[{"EventSource":"aws:sns","Sns":{"MessageId":"1",` +
+				`"TopicArn":"arn:aws:sns:us-east-1:123456789012:events",` +
+				`"MessageAttributes":{"traceparent":{"Type":"String","Value":"` + testTraceparent + `"}}}}]}`,
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemKey.String("aws_sns"),
+				semconv.MessagingOperationTypeProcess,
+				semconv.MessagingDestinationName("events"),
+				semconv.MessagingMessageID("1"),
+			},
+			carrier: propagation.MapCarrier{"traceparent": testTraceparent},
+		},
+		{
+			name: "Kinesis",
+			event: `{"Records":[{"eventSource":"aws:kinesis",` +
+				`"eventSourceARN":"arn:aws:kinesis:us-east-1:123456789012:stream/clicks"}]}`,
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemKey.String("aws_kinesis"),
+				semconv.MessagingOperationTypeProcess,
+				semconv.AWSKinesisStreamName("clicks"),
+			},
+		},
+		{
+			name:    "S3",
+			event:   `{"Records":[{"eventSource":"aws:s3","s3":{"bucket":{"name":"uploads"},"object":{"key":"a.txt"}}}]}`,
+			trigger: semconv.FaaSTriggerDatasource,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.AWSS3Bucket("uploads"),
+				semconv.AWSS3Key("a.txt"),
+			},
+		},
+		{
+			name:    "EventBridge",
+			event:   `{"source":"com.example.orders","detail-type":"OrderPlaced","detail":{}}`,
+			trigger: semconv.FaaSTriggerPubSub,
+			kind:    trace.SpanKindConsumer,
+			attrs: []attribute.KeyValue{
+				semconv.MessagingSystemKey.String("aws_eventbridge"),
+				semconv.MessagingOperationTypeProcess,
+			},
+		},
+		{
+			name:    "EventBridge schedule",
+			event:   `{"source":"aws.events","detail-type":"Scheduled Event","detail":{}}`,
+			trigger: semconv.FaaSTriggerTimer,
+			kind:    trace.SpanKindServer,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			src := detectEventSource([]byte(tc.event))
+			assert.Equal(t, tc.trigger, src.trigger)
+			assert.Equal(t, tc.kind, src.kind)
+			assert.Equal(t, tc.attrs, src.attrs)
+			assert.Equal(t, tc.carrier, src.carrier)
+			assert.Len(t, src.records, tc.records)
+		})
+	}
+}
+
+func TestEventSourceEventToCarrier(t *testing.T) {
+	c := EventSourceEventToCarrier([]byte(`{"httpMethod":"GET","headers":{"traceparent":"` + testTraceparent + `"}}`))
+	assert.Equal(t, testTraceparent, c.Get("traceparent"))
+
+	c = EventSourceEventToCarrier([]byte(`{"name":"Lambda"}`))
+	assert.Equal(t, propagation.HeaderCarrier{}, c)
+}
+
+func TestEventSourceLinks(t *testing.T) {
+	src := detectEventSource([]byte(`{"Records":[` +
+		`{"messageId":"1","eventSource":"aws:sqs","messageAttributes":{"traceparent":{"stringValue":"` + testTraceparent + `"}}},` +
+		`{"messageId":"2","eventSource":"aws:sqs"}]}`))
+
+	links := src.links(t.Context(), propagation.TraceContext{})
+	if assert.Len(t, links, 1) {
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", links[0].SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", links[0].SpanContext.SpanID().String())
+		assert.Equal(t, []attribute.KeyValue{semconv.MessagingMessageID("1")}, links[0].Attributes)
+	}
+}
@@ -8,11 +8,15 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/faasconv"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -27,34 +31,90 @@ type instrumentor struct {
 	configuration config
 	resAttrs      []attribute.KeyValue
 	tracer        trace.Tracer
+
+	invokeDuration faasconv.InvokeDuration
+	errors         faasconv.Errors
+	coldstarts     faasconv.Coldstarts
+
+	// warm is set once the first invocation started. It is shared by the
+	// copies of the instrumentor.
+	warm *atomic.Bool
+}
+
+// invocation holds the state of an instrumented invocation.
+type invocation struct {
+	span    trace.Span
+	start   time.Time
+	trigger attribute.KeyValue
 }
 
 func newInstrumentor(opts ...Option) instrumentor {
 	cfg := config{
 		TracerProvider:   otel.GetTracerProvider(),
+		MeterProvider:    otel.GetMeterProvider(),
 		Flusher:          &noopFlusher{},
-		EventToCarrier:   emptyEventToCarrier,
 		Propagator:       otel.GetTextMapPropagator(),
 		TraceAttributeFn: emptyTraceAttributeFn,
 	}
 	for _, opt := range opts {
 		opt.apply(&cfg)
 	}
+	if cfg.EventToCarrier == nil && !cfg.EventSourceDetection {
+		cfg.EventToCarrier = emptyEventToCarrier
+	}
+
+	meter := cfg.MeterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(Version))
+	invokeDuration, err := faasconv.NewInvokeDuration(meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	errs, err := faasconv.NewErrors(meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	coldstarts, err := faasconv.NewColdstarts(meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+
+	warm := &atomic.Bool{}
+	// Execution environments initialized for provisioned concurrency are
+	// not cold when they receive their first invocation.
+	if os.Getenv("AWS_LAMBDA_INITIALIZATION_TYPE") == "provisioned-concurrency" {
+		warm.Store(true)
+	}
 
 	return instrumentor{
-		configuration: cfg,
-		tracer:        cfg.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(Version)),
-		resAttrs:      []attribute.KeyValue{},
+		configuration:  cfg,
+		tracer:         cfg.TracerProvider.Tracer(ScopeName, trace.WithInstrumentationVersion(Version)),
+		resAttrs:       []attribute.KeyValue{},
+		invokeDuration: invokeDuration,
+		errors:         errs,
+		coldstarts:     coldstarts,
+		warm:           warm,
 	}
 }
 
 // Logic to start OTel Tracing.
-func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, trace.Span) {
+func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (context.Context, invocation) {
+	inv := invocation{start: time.Now()}
+	coldStart := !i.warm.Swap(true)
+
+	src := eventSource{kind: trace.SpanKindServer}
+	if i.configuration.EventSourceDetection {
+		src = detectEventSource(eventJSON)
+		inv.trigger = src.trigger
+	}
+
 	// Add trace id to context
-	mc := i.configuration.EventToCarrier(eventJSON)
+	mc := src.carrier
+	if i.configuration.EventToCarrier != nil {
+		mc = i.configuration.EventToCarrier(eventJSON)
+	} else if mc == nil {
+		mc = emptyEventToCarrier(eventJSON)
+	}
 	ctx = i.configuration.Propagator.Extract(ctx, mc)
 
-	var span trace.Span
 	spanName := os.Getenv("AWS_LAMBDA_FUNCTION_NAME")
 
 	var attributes []attribute.KeyValue
@@ -82,19 +142,49 @@ func (i *instrumentor) tracingBegin(ctx context.Context, eventJSON []byte) (cont
 		}
 		attributes = append(attributes, i.resAttrs...)
 	}
+	attributes = append(attributes, semconv.FaaSColdstart(coldStart))
+	if inv.trigger.Valid() {
+		attributes = append(attributes, inv.trigger)
+	}
+	attributes = append(attributes, src.attrs...)
 
-	ctx, span = i.tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindServer), trace.WithAttributes(attributes...))
+	ctx, inv.span = i.tracer.Start(ctx, spanName,
+		trace.WithSpanKind(src.kind),
+		trace.WithAttributes(attributes...),
+		trace.WithLinks(src.links(ctx, i.configuration.Propagator)...),
+	)
 
-	return ctx, span
+	if coldStart {
+		i.coldstarts.Add(ctx, 1, i.metricAttributes(inv)...)
+	}
+
+	return ctx, inv
 }
 
 // Logic to wrap up OTel Tracing.
-func (i *instrumentor) tracingEnd(ctx context.Context, span trace.Span) {
-	span.End()
+func (i *instrumentor) tracingEnd(ctx context.Context, inv invocation, err error) {
+	attrs := i.metricAttributes(inv)
+	if err != nil {
+		i.errors.Add(ctx, 1, attrs...)
+	}
+	i.invokeDuration.Record(ctx, time.Since(inv.start).Seconds(), attrs...)
+	inv.span.End()
 
 	// force flush any tracing data since lambda may freeze
-	err := i.configuration.Flusher.ForceFlush(ctx)
+	err = i.configuration.Flusher.ForceFlush(ctx)
 	if err != nil {
 		errorLogger.Println("failed to force a flush, lambda may freeze before instrumentation exported: ", err)
 	}
+	if f, ok := i.configuration.MeterProvider.(Flusher); ok {
+		if err := f.ForceFlush(ctx); err != nil {
+			errorLogger.Println("failed to force a flush of metrics, lambda may freeze before instrumentation exported: ", err)
+		}
+	}
+}
+
+func (*instrumentor) metricAttributes(inv invocation) []attribute.KeyValue {
+	if !inv.trigger.Valid() {
+		return nil
+	}
+	return []attribute.KeyValue{inv.trigger}
 }
@@ -6,6 +6,7 @@ package otellambda_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
@@ -18,9 +19,12 @@ import (
 	"github.com/aws/aws-lambda-go/lambda"
 	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
@@ -130,6 +134,7 @@ var (
 			attribute.String("faas.invocation_id", "123"),
 			attribute.String("aws.lambda.invoked_arn", "arn:partition:service:region:account-id:resource-type:resource-id"),
 			attribute.String("cloud.account.id", "account-id"),
+			attribute.Bool("faas.coldstart", true),
 		},
 		Events:            nil,
 		Links:             nil,
@@ -325,6 +330,7 @@ var (
 			attribute.String("faas.invocation_id", "123"),
 			attribute.String("aws.lambda.invoked_arn", "arn:partition:service:region:account-id:resource-type:resource-id"),
 			attribute.String("cloud.account.id", "account-id"),
+			attribute.Bool("faas.coldstart", true),
 		},
 		Events:            nil,
 		Links:             nil,
@@ -431,3 +437,108 @@ func TestWrapHandlerTracingWithTraceAttributeFn(t *testing.T) {
 	expectedAttr := attribute.KeyValue{Key: "mock.request.type", Value: attribute.StringValue(reflect.TypeFor[mockRequest]().String())}
 	assert.Contains(t, stub.Attributes, expectedAttr, "custom attribute 'mock.request.type' with value 'otellambda_test.mockRequest' not found")
 }
+
+func TestWrapHandlerColdStart(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{}, otellambda.WithTracerProvider(tp))
+	for range 2 {
+		_, err := wrapped.Invoke(mockContext, []byte{})
+		assert.NoError(t, err)
+	}
+
+	spans := memExporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Contains(t, spans[0].Attributes, attribute.Bool("faas.coldstart", true))
+	assert.Contains(t, spans[1].Attributes, attribute.Bool("faas.coldstart", false))
+}
+
+type flushingMeterProvider struct {
+	*sdkmetric.MeterProvider
+	flushCount int
+}
+
+func (mp *flushingMeterProvider) ForceFlush(ctx context.Context) error {
+	mp.flushCount++
+	return mp.MeterProvider.ForceFlush(ctx)
+}
+
+func TestInstrumentHandlerMetrics(t *testing.T) {
+	setEnvVars(t)
+	tp, _ := initMockTracerProvider()
+	reader := sdkmetric.NewManualReader()
+	mp := &flushingMeterProvider{MeterProvider: sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))}
+
+	customerHandler := func(_ context.Context, event map[string]any) error {
+		if event["fail"] == true {
+			return errors.New("bad stuff")
+		}
+		return nil
+	}
+
+	wrapped := otellambda.InstrumentHandler(customerHandler,
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithMeterProvider(mp),
+		otellambda.WithEventSourceDetection(),
+	)
+	wrappedCallable := reflect.ValueOf(wrapped)
+	for _, event := range []map[string]any{{"fail": false}, {"fail": true}} {
+		wrappedCallable.Call([]reflect.Value{reflect.ValueOf(mockContext), reflect.ValueOf(event)})
+	}
+	assert.Equal(t, 2, mp.flushCount)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, otellambda.ScopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	attrs := attribute.NewSet(attribute.String("faas.trigger", "other"))
+	metrics := rm.ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 3)
+	for _, m := range metrics {
+		switch m.Name {
+		case "faas.invoke_duration":
+			h, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, h.DataPoints, 1)
+			assert.Equal(t, uint64(2), h.DataPoints[0].Count)
+			assert.Equal(t, attrs, h.DataPoints[0].Attributes)
+		case "faas.errors", "faas.coldstarts":
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			require.True(t, ok)
+			require.Len(t, sum.DataPoints, 1)
+			assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+			assert.Equal(t, attrs, sum.DataPoints[0].Attributes)
+		default:
+			t.Errorf("unexpected metric %s", m.Name)
+		}
+	}
+}
+
+func TestWrapHandlerEventSourceDetection(t *testing.T) {
+	setEnvVars(t)
+	tp, memExporter := initMockTracerProvider()
+
+	wrapped := otellambda.WrapHandler(emptyHandler{},
+		otellambda.WithTracerProvider(tp),
+		otellambda.WithPropagator(propagation.TraceContext{}),
+		otellambda.WithEventSourceDetection(),
+	)
+
+	payload := []byte(`{"Records":[{"messageId":"1","eventSource":"aws:sqs",` +
+		`"eventSourceARN":"arn:aws:sqs:us-east-1:123456789012:orders","messageAttributes":{"traceparent":` +
+		`{"stringValue":"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01","dataType":"String"}}}]}`)
+	_, err := wrapped.Invoke(mockContext, payload)
+	assert.NoError(t, err)
+
+	require.Len(t, memExporter.GetSpans(), 1)
+	stub := memExporter.GetSpans()[0]
+	assert.Equal(t, trace.SpanKindConsumer, stub.SpanKind)
+	assert.Equal(t, trace.SpanContextFromContext(mockContext), stub.Parent)
+	assert.Contains(t, stub.Attributes, semconv.FaaSTriggerPubSub)
+	assert.Contains(t, stub.Attributes, semconv.MessagingDestinationName("orders"))
+	require.Len(t, stub.Links, 1)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", stub.Links[0].SpanContext.TraceID().String())
+	assert.Equal(t, []attribute.KeyValue{semconv.MessagingMessageID("1")}, stub.Links[0].Attributes)
+}
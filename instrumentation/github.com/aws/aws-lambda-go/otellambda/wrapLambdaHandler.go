@@ -141,8 +141,9 @@ func InstrumentHandler(handlerFunc any, options ...Option) any {
 // Adds OTel span surrounding customer handler call.
 func (whf *wrappedHandlerFunction) wrapper(handlerFunc any) func(ctx context.Context, eventJSON []byte, event any, takesContext bool) []reflect.Value {
 	return func(ctx context.Context, eventJSON []byte, event any, takesContext bool) []reflect.Value {
-		ctx, span := whf.instrumentor.tracingBegin(ctx, eventJSON)
-		defer whf.instrumentor.tracingEnd(ctx, span)
+		ctx, inv := whf.instrumentor.tracingBegin(ctx, eventJSON)
+		var err error
+		defer func() { whf.instrumentor.tracingEnd(ctx, inv, err) }()
 
 		handler := reflect.ValueOf(handlerFunc)
 		var args []reflect.Value
@@ -155,6 +156,10 @@ func (whf *wrappedHandlerFunction) wrapper(handlerFunc any) func(ctx context.Con
 
 		response := handler.Call(args)
 
+		if len(response) > 0 {
+			err, _ = response[len(response)-1].Interface().(error)
+		}
+
 		return response
 	}
 }
@@ -7,7 +7,11 @@ import (
 	"context"
 
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	"github.com/aws/smithy-go/middleware"
@@ -23,9 +27,13 @@ const (
 )
 
 var servicemap = map[string]AttributeBuilder{
-	dynamodb.ServiceID: DynamoDBAttributeBuilder,
-	sqs.ServiceID:      SQSAttributeBuilder,
-	sns.ServiceID:      SNSAttributeBuilder,
+	dynamodb.ServiceID:       DynamoDBAttributeBuilder,
+	sqs.ServiceID:            SQSAttributeBuilder,
+	sns.ServiceID:            SNSAttributeBuilder,
+	s3.ServiceID:             S3AttributeBuilder,
+	kinesis.ServiceID:        KinesisAttributeBuilder,
+	lambda.ServiceID:         LambdaAttributeBuilder,
+	bedrockruntime.ServiceID: BedrockRuntimeAttributeBuilder,
 }
 
 // SystemAttr return the AWS RPC system attribute.
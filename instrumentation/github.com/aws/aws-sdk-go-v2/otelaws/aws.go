@@ -9,13 +9,16 @@ import (
 	"time"
 
 	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/smithy-go/middleware"
 	smithyhttp "github.com/aws/smithy-go/transport/http"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/rpcconv"
 	"go.opentelemetry.io/otel/trace"
 )
 
@@ -31,8 +34,12 @@ type AttributeBuilder func(ctx context.Context, in middleware.InitializeInput, o
 
 type otelMiddlewares struct {
 	tracer            trace.Tracer
+	callDuration      rpcconv.ClientCallDuration
+	retries           metric.Int64Counter
 	propagator        propagation.TextMapPropagator
 	attributeBuilders []AttributeBuilder
+
+	messagePropagation bool
 }
 
 func (otelMiddlewares) initializeMiddlewareBefore(stack *middleware.Stack) error {
@@ -61,26 +68,48 @@ func (m otelMiddlewares) initializeMiddlewareAfter(stack *middleware.Stack) erro
 			RegionAttr(region),
 		}
 
+		start := ctx.Value(spanTimestampKey{}).(time.Time)
 		ctx, span := m.tracer.Start(
 			ctx, spanName(serviceID, operation),
-			trace.WithTimestamp(ctx.Value(spanTimestampKey{}).(time.Time)),
+			trace.WithTimestamp(start),
 			trace.WithSpanKind(trace.SpanKindClient),
 			trace.WithAttributes(attributes...),
 		)
 		defer span.End()
 
+		if m.messagePropagation {
+			in.Parameters = m.injectMessageAttributes(ctx, in.Parameters)
+		}
+
 		out, metadata, err = next.HandleInitialize(ctx, in)
+		if m.messagePropagation && err == nil {
+			m.addMessageLinks(ctx, out.Result)
+		}
 		span.SetAttributes(m.buildAttributes(ctx, in, out)...)
 		if err != nil {
 			span.SetAttributes(semconv.ErrorType(err))
 			span.SetStatus(codes.Error, err.Error())
 		}
+		m.recordMetrics(ctx, time.Since(start), []attribute.KeyValue{MethodAttr(serviceID, operation), RegionAttr(region)}, metadata, err)
 
 		return out, metadata, err
 	}),
 		middleware.After)
 }
 
+// recordMetrics records the duration and the number of retries of an
+// operation. The attrs identify the operation and its region.
+func (m otelMiddlewares) recordMetrics(ctx context.Context, d time.Duration, attrs []attribute.KeyValue, metadata middleware.Metadata, err error) {
+	if results, ok := retry.GetAttemptResults(metadata); ok && len(results.Results) > 1 {
+		m.retries.Add(ctx, int64(len(results.Results)-1), metric.WithAttributes(attrs...))
+	}
+
+	if err != nil {
+		attrs = append(attrs[:len(attrs):len(attrs)], semconv.ErrorType(err))
+	}
+	m.callDuration.Record(ctx, d.Seconds(), rpcconv.SystemNameAttr(AWSSystemVal), attrs...)
+}
+
 func (m otelMiddlewares) finalizeMiddlewareAfter(stack *middleware.Stack) error {
 	return stack.Finalize.Add(middleware.FinalizeMiddlewareFunc("OTelFinalizeMiddleware", func(
 		ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
@@ -143,23 +172,34 @@ func spanName(serviceID, operation string) string {
 // OTel middlewares can be appended to either all aws clients or a specific operation.
 // Please see more details in https://docs.aws.amazon.com/sdk-for-go/v2/developer-guide/middleware.html
 func AppendMiddlewares(apiOptions *[]func(*middleware.Stack) error, opts ...Option) {
-	cfg := config{
-		TracerProvider:    otel.GetTracerProvider(),
-		TextMapPropagator: otel.GetTextMapPropagator(),
-	}
-	for _, opt := range opts {
-		opt.apply(&cfg)
-	}
-
+	cfg := newConfig(opts...)
 	if cfg.AttributeBuilders == nil {
 		cfg.AttributeBuilders = []AttributeBuilder{DefaultAttributeBuilder}
 	}
 
+	meter := cfg.MeterProvider.Meter(ScopeName, metric.WithInstrumentationVersion(Version))
+	callDuration, err := rpcconv.NewClientCallDuration(meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	// Experimental, see WithMeterProvider.
+	retries, err := meter.Int64Counter(
+		"aws.client.retries",
+		metric.WithDescription("The number of times operations were retried by the AWS SDK retryer."),
+		metric.WithUnit("{retry}"),
+	)
+	if err != nil {
+		otel.Handle(err)
+	}
+
 	m := otelMiddlewares{
 		tracer: cfg.TracerProvider.Tracer(ScopeName,
 			trace.WithInstrumentationVersion(Version)),
-		propagator:        cfg.TextMapPropagator,
-		attributeBuilders: cfg.AttributeBuilders,
+		callDuration:       callDuration,
+		retries:            retries,
+		propagator:         cfg.TextMapPropagator,
+		attributeBuilders:  cfg.AttributeBuilders,
+		messagePropagation: cfg.MessageAttributePropagation,
 	}
 	*apiOptions = append(*apiOptions, m.initializeMiddlewareBefore, m.initializeMiddlewareAfter, m.finalizeMiddlewareAfter, m.deserializeMiddleware)
 }
@@ -8,8 +8,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/route53/types"
 	smithyauth "github.com/aws/smithy-go/auth"
@@ -18,6 +20,9 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
@@ -151,3 +156,93 @@ func TestAppendMiddlewares(t *testing.T) {
 		srv.Close()
 	}
 }
+
+func TestAppendMiddlewaresMetrics(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+		<ChangeResourceRecordSetsResponse>
+			<ChangeInfo><Id>mockID</Id></ChangeInfo>
+		</ChangeResourceRecordSetsResponse>`))
+	}))
+	defer srv.Close()
+
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	svc := route53.New(route53.Options{
+		Region:             "us-west-2",
+		BaseEndpoint:       &srv.URL,
+		AuthSchemeResolver: &route53AuthResolver{},
+		AuthSchemes: []smithyhttp.AuthScheme{
+			smithyhttp.NewAnonymousScheme(),
+		},
+		Retryer: retry.NewStandard(func(o *retry.StandardOptions) {
+			o.Backoff = retry.BackoffDelayerFunc(func(int, error) (time.Duration, error) {
+				return 0, nil
+			})
+		}),
+	})
+
+	_, err := svc.ChangeResourceRecordSets(t.Context(), &route53.ChangeResourceRecordSetsInput{
+		ChangeBatch: &types.ChangeBatch{
+			Changes: []types.Change{},
+			Comment: aws.String("mock"),
+		},
+		HostedZoneId: aws.String("zone"),
+	}, func(options *route53.Options) {
+		otelaws.AppendMiddlewares(
+			&options.APIOptions,
+			otelaws.WithTracerProvider(sdktrace.NewTracerProvider()),
+			otelaws.WithMeterProvider(provider),
+		)
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	assert.Equal(t, otelaws.ScopeName, rm.ScopeMetrics[0].Scope.Name)
+
+	attrs := attribute.NewSet(
+		attribute.String("rpc.system.name", "aws-api"),
+		attribute.String("rpc.method", "Route 53/ChangeResourceRecordSets"),
+		attribute.String("aws.region", "us-west-2"),
+	)
+	retryAttrs := attribute.NewSet(
+		attribute.String("rpc.method", "Route 53/ChangeResourceRecordSets"),
+		attribute.String("aws.region", "us-west-2"),
+	)
+
+	metrics := rm.ScopeMetrics[0].Metrics
+	require.Len(t, metrics, 2)
+	for _, m := range metrics {
+		switch m.Name {
+		case "rpc.client.call.duration":
+			h, ok := m.Data.(metricdata.Histogram[float64])
+			require.True(t, ok)
+			require.Len(t, h.DataPoints, 1)
+			assert.Equal(t, uint64(1), h.DataPoints[0].Count)
+			assert.Equal(t, attrs, h.DataPoints[0].Attributes)
+		case "aws.client.retries":
+			metricdatatest.AssertEqual(t, metricdata.Metrics{
+				Name:        "aws.client.retries",
+				Description: "The number of times operations were retried by the AWS SDK retryer.",
+				Unit:        "{retry}",
+				Data: metricdata.Sum[int64]{
+					Temporality: metricdata.CumulativeTemporality,
+					IsMonotonic: true,
+					DataPoints:  []metricdata.DataPoint[int64]{{Attributes: retryAttrs, Value: 2}},
+				},
+			}, m, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreExemplars())
+		default:
+			t.Errorf("unexpected metric %q", m.Name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+// Generative AI attributes. They are not part of the semconv version used
+// by this package.
+const (
+	genAIProviderNameKey          = attribute.Key("gen_ai.provider.name")
+	genAIOperationNameKey         = attribute.Key("gen_ai.operation.name")
+	genAIRequestModelKey          = attribute.Key("gen_ai.request.model")
+	genAIRequestMaxTokensKey      = attribute.Key("gen_ai.request.max_tokens")
+	genAIRequestTemperatureKey    = attribute.Key("gen_ai.request.temperature")
+	genAIRequestTopPKey           = attribute.Key("gen_ai.request.top_p")
+	genAIRequestStopSequencesKey  = attribute.Key("gen_ai.request.stop_sequences")
+	genAIResponseFinishReasonsKey = attribute.Key("gen_ai.response.finish_reasons")
+	genAIUsageInputTokensKey      = attribute.Key("gen_ai.usage.input_tokens")
+	genAIUsageOutputTokensKey     = attribute.Key("gen_ai.usage.output_tokens")
+)
+
+// BedrockRuntimeAttributeBuilder sets Bedrock Runtime specific attributes depending on the Bedrock Runtime operation being performed.
+// The token usage is recorded for the Converse operation, the only one returning it in a model independent format.
+func BedrockRuntimeAttributeBuilder(_ context.Context, in middleware.InitializeInput, out middleware.InitializeOutput) []attribute.KeyValue {
+	bedrockAttributes := []attribute.KeyValue{genAIProviderNameKey.String("aws.bedrock")}
+
+	switch v := in.Parameters.(type) {
+	case *bedrockruntime.ConverseInput:
+		bedrockAttributes = append(bedrockAttributes, genAIOperationNameKey.String("chat"))
+		bedrockAttributes = appendModel(bedrockAttributes, v.ModelId)
+		bedrockAttributes = appendInferenceConfig(bedrockAttributes, v.InferenceConfig)
+		if v.GuardrailConfig != nil && v.GuardrailConfig.GuardrailIdentifier != nil {
+			bedrockAttributes = append(bedrockAttributes, semconv.AWSBedrockGuardrailID(*v.GuardrailConfig.GuardrailIdentifier))
+		}
+	case *bedrockruntime.ConverseStreamInput:
+		bedrockAttributes = append(bedrockAttributes, genAIOperationNameKey.String("chat"))
+		bedrockAttributes = appendModel(bedrockAttributes, v.ModelId)
+		bedrockAttributes = appendInferenceConfig(bedrockAttributes, v.InferenceConfig)
+		if v.GuardrailConfig != nil && v.GuardrailConfig.GuardrailIdentifier != nil {
+			bedrockAttributes = append(bedrockAttributes, semconv.AWSBedrockGuardrailID(*v.GuardrailConfig.GuardrailIdentifier))
+		}
+	case *bedrockruntime.InvokeModelInput:
+		bedrockAttributes = appendModel(bedrockAttributes, v.ModelId)
+		if v.GuardrailIdentifier != nil {
+			bedrockAttributes = append(bedrockAttributes, semconv.AWSBedrockGuardrailID(*v.GuardrailIdentifier))
+		}
+	case *bedrockruntime.InvokeModelWithResponseStreamInput:
+		bedrockAttributes = appendModel(bedrockAttributes, v.ModelId)
+		if v.GuardrailIdentifier != nil {
+			bedrockAttributes = append(bedrockAttributes, semconv.AWSBedrockGuardrailID(*v.GuardrailIdentifier))
+		}
+	}
+
+	if v, ok := out.Result.(*bedrockruntime.ConverseOutput); ok && v != nil {
+		if v.StopReason != "" {
+			bedrockAttributes = append(bedrockAttributes, genAIResponseFinishReasonsKey.StringSlice([]string{string(v.StopReason)}))
+		}
+		if v.Usage != nil {
+			if v.Usage.InputTokens != nil {
+				bedrockAttributes = append(bedrockAttributes, genAIUsageInputTokensKey.Int(int(*v.Usage.InputTokens)))
+			}
+			if v.Usage.OutputTokens != nil {
+				bedrockAttributes = append(bedrockAttributes, genAIUsageOutputTokensKey.Int(int(*v.Usage.OutputTokens)))
+			}
+		}
+	}
+
+	return bedrockAttributes
+}
+
+func appendModel(attrs []attribute.KeyValue, modelID *string) []attribute.KeyValue {
+	if modelID == nil {
+		return attrs
+	}
+	return append(attrs, genAIRequestModelKey.String(*modelID))
+}
+
+func appendInferenceConfig(attrs []attribute.KeyValue, cfg *types.InferenceConfiguration) []attribute.KeyValue {
+	if cfg == nil {
+		return attrs
+	}
+	if cfg.MaxTokens != nil {
+		attrs = append(attrs, genAIRequestMaxTokensKey.Int(int(*cfg.MaxTokens)))
+	}
+	if cfg.Temperature != nil {
+		attrs = append(attrs, genAIRequestTemperatureKey.Float64(float64(*cfg.Temperature)))
+	}
+	if cfg.TopP != nil {
+		attrs = append(attrs, genAIRequestTopPKey.Float64(float64(*cfg.TopP)))
+	}
+	if len(cfg.StopSequences) > 0 {
+		attrs = append(attrs, genAIRequestStopSequencesKey.StringSlice(cfg.StopSequences))
+	}
+	return attrs
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime/types"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+func TestBedrockRuntimeConverse(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &bedrockruntime.ConverseInput{
+			ModelId: aws.String("anthropic.claude-3-haiku"),
+			InferenceConfig: &types.InferenceConfiguration{
+				MaxTokens:   aws.Int32(512),
+				Temperature: aws.Float32(0.5),
+				TopP:        aws.Float32(0.25),
+			},
+			GuardrailConfig: &types.GuardrailConfiguration{
+				GuardrailIdentifier: aws.String("guardrail"),
+			},
+		},
+	}
+	output := middleware.InitializeOutput{
+		Result: &bedrockruntime.ConverseOutput{
+			StopReason: types.StopReasonEndTurn,
+			Usage: &types.TokenUsage{
+				InputTokens:  aws.Int32(10),
+				OutputTokens: aws.Int32(20),
+			},
+		},
+	}
+
+	attributes := BedrockRuntimeAttributeBuilder(t.Context(), input, output)
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("gen_ai.provider.name", "aws.bedrock"),
+		attribute.String("gen_ai.operation.name", "chat"),
+		attribute.String("gen_ai.request.model", "anthropic.claude-3-haiku"),
+		attribute.Int("gen_ai.request.max_tokens", 512),
+		attribute.Float64("gen_ai.request.temperature", 0.5),
+		attribute.Float64("gen_ai.request.top_p", 0.25),
+		semconv.AWSBedrockGuardrailID("guardrail"),
+		attribute.StringSlice("gen_ai.response.finish_reasons", []string{"end_turn"}),
+		attribute.Int("gen_ai.usage.input_tokens", 10),
+		attribute.Int("gen_ai.usage.output_tokens", 20),
+	}, attributes)
+}
+
+func TestBedrockRuntimeInvokeModel(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &bedrockruntime.InvokeModelInput{
+			ModelId: aws.String("amazon.titan-text-express-v1"),
+		},
+	}
+
+	attributes := BedrockRuntimeAttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("gen_ai.provider.name", "aws.bedrock"),
+		attribute.String("gen_ai.request.model", "amazon.titan-text-express-v1"),
+	}, attributes)
+}
@@ -4,14 +4,32 @@
 package otelaws
 
 import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type config struct {
 	TracerProvider    trace.TracerProvider
+	MeterProvider     metric.MeterProvider
 	TextMapPropagator propagation.TextMapPropagator
 	AttributeBuilders []AttributeBuilder
+
+	MessageAttributePropagation bool
+}
+
+// newConfig returns a config with all Options set.
+func newConfig(opts ...Option) config {
+	cfg := config{
+		TracerProvider:    otel.GetTracerProvider(),
+		MeterProvider:     otel.GetMeterProvider(),
+		TextMapPropagator: otel.GetTextMapPropagator(),
+	}
+	for _, opt := range opts {
+		opt.apply(&cfg)
+	}
+	return cfg
 }
 
 // Option applies an option value.
@@ -37,6 +55,21 @@ func WithTracerProvider(provider trace.TracerProvider) Option {
 	})
 }
 
+// WithMeterProvider specifies a meter provider to use for creating a meter.
+// If none is specified, the global MeterProvider is used.
+//
+// The meter records the rpc.client.call.duration histogram and the
+// experimental aws.client.retries counter. The aws.client.retries counter is
+// not defined by the semantic conventions and may be renamed or removed in a
+// future release.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return optionFunc(func(cfg *config) {
+		if provider != nil {
+			cfg.MeterProvider = provider
+		}
+	})
+}
+
 // WithTextMapPropagator specifies a Text Map Propagator to use when propagating context.
 // If none is specified, the global TextMapPropagator is used.
 func WithTextMapPropagator(propagator propagation.TextMapPropagator) Option {
@@ -54,3 +87,23 @@ func WithAttributeBuilder(attributeBuilders ...AttributeBuilder) Option {
 		cfg.AttributeBuilders = append(cfg.AttributeBuilders, attributeBuilders...)
 	})
 }
+
+// WithMessageAttributePropagation specifies that the trace context is
+// propagated in the message attributes of the messages sent by SQS
+// SendMessage and SendMessageBatch and SNS Publish and PublishBatch
+// operations, so it reaches the consumers of the messages. The trace context
+// is not injected into messages that would exceed the limit of 10 message
+// attributes.
+//
+// The propagator fields are also added to the message attributes requested
+// by SQS ReceiveMessage operations, and the span of the operation is linked
+// to the trace context of every received message. Use [SQSMessageLinks] or
+// [ExtractSQSMessage] to link or parent the spans processing the messages.
+//
+// SNS notifications delivered to SQS queues only carry the message
+// attributes if raw message delivery is enabled on the subscription.
+func WithMessageAttributePropagation() Option {
+	return optionFunc(func(cfg *config) {
+		cfg.MessageAttributePropagation = true
+	})
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+// KinesisAttributeBuilder sets Kinesis specific attributes depending on the Kinesis operation being performed.
+func KinesisAttributeBuilder(_ context.Context, in middleware.InitializeInput, _ middleware.InitializeOutput) []attribute.KeyValue {
+	var name, arn *string
+
+	switch v := in.Parameters.(type) {
+	case *kinesis.AddTagsToStreamInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.CreateStreamInput:
+		name = v.StreamName
+	case *kinesis.DecreaseStreamRetentionPeriodInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.DeleteStreamInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.DescribeStreamInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.DescribeStreamSummaryInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.GetRecordsInput:
+		arn = v.StreamARN
+	case *kinesis.GetShardIteratorInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.IncreaseStreamRetentionPeriodInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.ListShardsInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.MergeShardsInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.PutRecordInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.PutRecordsInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.RegisterStreamConsumerInput:
+		arn = v.StreamARN
+	case *kinesis.SplitShardInput:
+		name, arn = v.StreamName, v.StreamARN
+	case *kinesis.UpdateShardCountInput:
+		name, arn = v.StreamName, v.StreamARN
+	}
+
+	if name != nil && *name != "" {
+		return []attribute.KeyValue{semconv.AWSKinesisStreamName(*name)}
+	}
+	if arn != nil {
+		// Stream ARNs are formatted as arn:aws:kinesis:<region>:<account>:stream/<name>.
+		if _, n, ok := strings.Cut(*arn, ":stream/"); ok && n != "" {
+			return []attribute.KeyValue{semconv.AWSKinesisStreamName(n)}
+		}
+	}
+	return []attribute.KeyValue{}
+}
@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kinesis"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+func TestKinesisPutRecordInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesis.PutRecordInput{
+			StreamName: aws.String("stream"),
+		},
+	}
+
+	attributes := KinesisAttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.Contains(t, attributes, semconv.AWSKinesisStreamName("stream"))
+}
+
+func TestKinesisGetRecordsInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesis.GetRecordsInput{
+			StreamARN: aws.String("arn:aws:kinesis:us-east-1:123456789012:stream/stream"),
+		},
+	}
+
+	attributes := KinesisAttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.Contains(t, attributes, semconv.AWSKinesisStreamName("stream"))
+}
+
+func TestKinesisNoStream(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &kinesis.ListStreamsInput{},
+	}
+
+	attributes := KinesisAttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.Empty(t, attributes)
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"context"
+	"strings"
+
+	v2Middleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+// LambdaAttributeBuilder sets Lambda specific attributes for the Lambda Invoke operations.
+func LambdaAttributeBuilder(ctx context.Context, in middleware.InitializeInput, _ middleware.InitializeOutput) []attribute.KeyValue {
+	var function *string
+
+	switch v := in.Parameters.(type) {
+	case *lambda.InvokeInput:
+		function = v.FunctionName
+	case *lambda.InvokeWithResponseStreamInput:
+		function = v.FunctionName
+	}
+	if function == nil || *function == "" {
+		return []attribute.KeyValue{}
+	}
+
+	name, region, arn := parseFunctionName(*function)
+	if region == "" {
+		region = v2Middleware.GetRegion(ctx)
+	}
+
+	lambdaAttributes := []attribute.KeyValue{
+		semconv.FaaSInvokedName(name),
+		semconv.FaaSInvokedProviderAWS,
+	}
+	if region != "" {
+		lambdaAttributes = append(lambdaAttributes, semconv.FaaSInvokedRegion(region))
+	}
+	if arn != "" {
+		lambdaAttributes = append(lambdaAttributes, semconv.AWSLambdaInvokedARN(arn))
+	}
+	return lambdaAttributes
+}
+
+// parseFunctionName returns the name, region and ARN of a Lambda function
+// identified by its name, ARN or partial ARN, optionally suffixed by a
+// version or alias:
+//   - my-function
+//   - 123456789012:function:my-function
+//   - arn:aws:lambda:us-west-2:123456789012:function:my-function:alias
+//
+// The region and ARN are only returned for full ARNs.
+func parseFunctionName(function string) (name, region, arn string) {
+	parts := strings.Split(function, ":")
+	switch {
+	case len(parts) >= 7 && parts[0] == "arn":
+		return parts[6], parts[3], function
+	case len(parts) >= 3 && parts[1] == "function":
+		return parts[2], "", ""
+	default:
+		return parts[0], "", ""
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsMiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/lambda"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+func TestLambdaInvokeInput(t *testing.T) {
+	ctx := awsMiddleware.SetRegion(t.Context(), "us-east-1")
+
+	tests := []struct {
+		function string
+		want     []attribute.KeyValue
+	}{
+		{
+			function: "my-function",
+			want: []attribute.KeyValue{
+				semconv.FaaSInvokedName("my-function"),
+				semconv.FaaSInvokedProviderAWS,
+				semconv.FaaSInvokedRegion("us-east-1"),
+			},
+		},
+		{
+			function: "123456789012:function:my-function",
+			want: []attribute.KeyValue{
+				semconv.FaaSInvokedName("my-function"),
+				semconv.FaaSInvokedProviderAWS,
+				semconv.FaaSInvokedRegion("us-east-1"),
+			},
+		},
+		{
+			function: "arn:aws:lambda:us-west-2:123456789012:function:my-function:prod",
+			want: []attribute.KeyValue{
+				semconv.FaaSInvokedName("my-function"),
+				semconv.FaaSInvokedProviderAWS,
+				semconv.FaaSInvokedRegion("us-west-2"),
+				semconv.AWSLambdaInvokedARN("arn:aws:lambda:us-west-2:123456789012:function:my-function:prod"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.function, func(t *testing.T) {
+			input := middleware.InitializeInput{
+				Parameters: &lambda.InvokeInput{
+					FunctionName: aws.String(tt.function),
+				},
+			}
+
+			attributes := LambdaAttributeBuilder(ctx, input, middleware.InitializeOutput{})
+
+			assert.Equal(t, tt.want, attributes)
+		})
+	}
+}
+
+func TestLambdaOtherInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &lambda.GetFunctionInput{
+			FunctionName: aws.String("my-function"),
+		},
+	}
+
+	attributes := LambdaAttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.Empty(t, attributes)
+}
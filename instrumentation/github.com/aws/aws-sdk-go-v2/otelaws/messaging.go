@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// maxMessageAttributes is the maximum number of message attributes of an SQS
+// message or SNS notification.
+const maxMessageAttributes = 10
+
+// SQSMessageAttributeCarrier adapts the message attributes of an SQS message
+// to satisfy the propagation.TextMapCarrier interface. Values are stored as
+// String message attributes.
+type SQSMessageAttributeCarrier map[string]sqstypes.MessageAttributeValue
+
+// Compile time check that SQSMessageAttributeCarrier implements the
+// TextMapCarrier.
+var _ propagation.TextMapCarrier = SQSMessageAttributeCarrier{}
+
+// Get returns the string value associated with the passed key.
+func (c SQSMessageAttributeCarrier) Get(key string) string {
+	return aws.ToString(c[key].StringValue)
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SQSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = sqstypes.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SQSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// SNSMessageAttributeCarrier adapts the message attributes of an SNS
+// notification to satisfy the propagation.TextMapCarrier interface. Values
+// are stored as String message attributes.
+type SNSMessageAttributeCarrier map[string]snstypes.MessageAttributeValue
+
+// Compile time check that SNSMessageAttributeCarrier implements the
+// TextMapCarrier.
+var _ propagation.TextMapCarrier = SNSMessageAttributeCarrier{}
+
+// Get returns the string value associated with the passed key.
+func (c SNSMessageAttributeCarrier) Get(key string) string {
+	return aws.ToString(c[key].StringValue)
+}
+
+// Set stores the key-value pair as a String message attribute.
+func (c SNSMessageAttributeCarrier) Set(key, value string) {
+	c[key] = snstypes.MessageAttributeValue{
+		DataType:    aws.String("String"),
+		StringValue: aws.String(value),
+	}
+}
+
+// Keys lists the keys stored in this carrier.
+func (c SNSMessageAttributeCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// injectSQS returns a copy of attrs with the trace context of ctx injected.
+// The attributes are returned unchanged if the injected fields would exceed
+// the message attribute limit.
+func (m otelMiddlewares) injectSQS(ctx context.Context, attrs map[string]sqstypes.MessageAttributeValue) map[string]sqstypes.MessageAttributeValue {
+	c := make(SQSMessageAttributeCarrier, len(attrs)+len(m.propagator.Fields()))
+	for k, v := range attrs {
+		c[k] = v
+	}
+	m.propagator.Inject(ctx, c)
+	if len(c) > maxMessageAttributes {
+		return attrs
+	}
+	return c
+}
+
+// injectSNS returns a copy of attrs with the trace context of ctx injected.
+// The attributes are returned unchanged if the injected fields would exceed
+// the message attribute limit.
+func (m otelMiddlewares) injectSNS(ctx context.Context, attrs map[string]snstypes.MessageAttributeValue) map[string]snstypes.MessageAttributeValue {
+	c := make(SNSMessageAttributeCarrier, len(attrs)+len(m.propagator.Fields()))
+	for k, v := range attrs {
+		c[k] = v
+	}
+	m.propagator.Inject(ctx, c)
+	if len(c) > maxMessageAttributes {
+		return attrs
+	}
+	return c
+}
+
+// injectMessageAttributes returns the operation parameters with the trace
+// context of ctx injected into the message attributes of the messages sent
+// by SQS SendMessage and SendMessageBatch and SNS Publish and PublishBatch
+// operations. For SQS ReceiveMessage operations, the propagator fields are
+// added to the requested message attribute names. The parameters of the
+// caller are never modified, a modified copy is returned instead.
+func (m otelMiddlewares) injectMessageAttributes(ctx context.Context, params any) any {
+	switch v := params.(type) {
+	case *sqs.SendMessageInput:
+		in := *v
+		in.MessageAttributes = m.injectSQS(ctx, v.MessageAttributes)
+		return &in
+	case *sqs.SendMessageBatchInput:
+		in := *v
+		in.Entries = make([]sqstypes.SendMessageBatchRequestEntry, len(v.Entries))
+		for i, e := range v.Entries {
+			e.MessageAttributes = m.injectSQS(ctx, e.MessageAttributes)
+			in.Entries[i] = e
+		}
+		return &in
+	case *sqs.ReceiveMessageInput:
+		names := v.MessageAttributeNames
+		for _, f := range m.propagator.Fields() {
+			if !slices.Contains(names, f) && !slices.Contains(names, "All") && !slices.Contains(names, ".*") {
+				names = append(names[:len(names):len(names)], f)
+			}
+		}
+		in := *v
+		in.MessageAttributeNames = names
+		return &in
+	case *sns.PublishInput:
+		in := *v
+		in.MessageAttributes = m.injectSNS(ctx, v.MessageAttributes)
+		return &in
+	case *sns.PublishBatchInput:
+		in := *v
+		in.PublishBatchRequestEntries = make([]snstypes.PublishBatchRequestEntry, len(v.PublishBatchRequestEntries))
+		for i, e := range v.PublishBatchRequestEntries {
+			e.MessageAttributes = m.injectSNS(ctx, e.MessageAttributes)
+			in.PublishBatchRequestEntries[i] = e
+		}
+		return &in
+	}
+	return params
+}
+
+// ExtractSQSMessage returns a copy of ctx with the trace context propagated
+// in the message attributes of msg. The propagator configured with
+// [WithTextMapPropagator] is used, or the global TextMapPropagator if none is
+// specified.
+//
+// The message attributes are only received if they are requested in the
+// MessageAttributeNames of the ReceiveMessageInput, which is done
+// automatically by the middlewares configured with
+// [WithMessageAttributePropagation].
+func ExtractSQSMessage(ctx context.Context, msg sqstypes.Message, opts ...Option) context.Context {
+	cfg := newConfig(opts...)
+	return cfg.TextMapPropagator.Extract(ctx, SQSMessageAttributeCarrier(msg.MessageAttributes))
+}
+
+// SQSMessageLinks returns a span link to the trace context propagated in the
+// message attributes of each of the messages, for example to link a span
+// processing the messages returned by a ReceiveMessage operation to the
+// spans that sent them. Messages without a valid trace context are skipped.
+// The propagator configured with [WithTextMapPropagator] is used, or the
+// global TextMapPropagator if none is specified.
+func SQSMessageLinks(ctx context.Context, msgs []sqstypes.Message, opts ...Option) []trace.Link {
+	cfg := newConfig(opts...)
+	return sqsMessageLinks(ctx, cfg.TextMapPropagator, msgs)
+}
+
+func sqsMessageLinks(ctx context.Context, propagator propagation.TextMapPropagator, msgs []sqstypes.Message) []trace.Link {
+	var links []trace.Link
+	for _, msg := range msgs {
+		// Extract into a context without a span, so the span of ctx is not
+		// returned for messages without a trace context.
+		mctx := propagator.Extract(trace.ContextWithSpanContext(ctx, trace.SpanContext{}), SQSMessageAttributeCarrier(msg.MessageAttributes))
+		sc := trace.SpanContextFromContext(mctx)
+		if !sc.IsValid() {
+			continue
+		}
+
+		var attrs []attribute.KeyValue
+		if msg.MessageId != nil {
+			attrs = append(attrs, semconv.MessagingMessageID(*msg.MessageId))
+		}
+		links = append(links, trace.Link{SpanContext: sc, Attributes: attrs})
+	}
+	return links
+}
+
+// addMessageLinks adds links to the span of ctx for the messages returned
+// by SQS ReceiveMessage operations.
+func (m otelMiddlewares) addMessageLinks(ctx context.Context, result any) {
+	out, ok := result.(*sqs.ReceiveMessageOutput)
+	if !ok || out == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	for _, l := range sqsMessageLinks(ctx, m.propagator, out.Messages) {
+		span.AddLink(l)
+	}
+}
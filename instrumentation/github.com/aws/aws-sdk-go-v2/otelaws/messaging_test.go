@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	producerSC = trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	traceparent = "00-01000000000000000000000000000000-0200000000000000-01"
+)
+
+func newSQSClient(t *testing.T, handler http.HandlerFunc, opts ...Option) *sqs.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := aws.Config{
+		Region:       "us-east-1",
+		BaseEndpoint: &srv.URL,
+		Credentials:  aws.AnonymousCredentials{},
+		Retryer:      func() aws.Retryer { return aws.NopRetryer{} },
+	}
+	AppendMiddlewares(&cfg.APIOptions, opts...)
+	return sqs.NewFromConfig(cfg)
+}
+
+func TestSQSSendMessagePropagation(t *testing.T) {
+	var body map[string]any
+	svc := newSQSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, &body))
+		_, _ = w.Write([]byte(`{"MessageId":"1"}`))
+	},
+		WithTracerProvider(sdktrace.NewTracerProvider()),
+		WithTextMapPropagator(propagation.TraceContext{}),
+		WithMessageAttributePropagation(),
+	)
+
+	in := &sqs.SendMessageInput{
+		QueueUrl:    aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/queue"),
+		MessageBody: aws.String("body"),
+		MessageAttributes: map[string]sqstypes.MessageAttributeValue{
+			"custom": {DataType: aws.String("String"), StringValue: aws.String("value")},
+		},
+	}
+	_, err := svc.SendMessage(t.Context(), in)
+	require.NoError(t, err)
+
+	// The input of the caller is not modified.
+	assert.Len(t, in.MessageAttributes, 1)
+
+	attrs, ok := body["MessageAttributes"].(map[string]any)
+	require.True(t, ok, "no message attributes sent")
+	assert.Contains(t, attrs, "custom")
+	require.Contains(t, attrs, "traceparent")
+	tp := attrs["traceparent"].(map[string]any)
+	assert.Equal(t, "String", tp["DataType"])
+	assert.Regexp(t, `^00-[0-9a-f]{32}-[0-9a-f]{16}-01$`, tp["StringValue"])
+}
+
+func TestSQSSendMessagePropagationDisabled(t *testing.T) {
+	var body map[string]any
+	svc := newSQSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, &body))
+		_, _ = w.Write([]byte(`{"MessageId":"1"}`))
+	},
+		WithTracerProvider(sdktrace.NewTracerProvider()),
+		WithTextMapPropagator(propagation.TraceContext{}),
+	)
+
+	_, err := svc.SendMessage(t.Context(), &sqs.SendMessageInput{
+		QueueUrl:    aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/queue"),
+		MessageBody: aws.String("body"),
+	})
+	require.NoError(t, err)
+	assert.NotContains(t, body, "MessageAttributes")
+}
+
+func TestSQSReceiveMessageLinks(t *testing.T) {
+	var body map[string]any
+	sr := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	opts := []Option{
+		WithTracerProvider(provider),
+		WithTextMapPropagator(propagation.TraceContext{}),
+		WithMessageAttributePropagation(),
+	}
+	svc := newSQSClient(t, func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(b, &body))
+		_, _ = w.Write([]byte(`{"Messages":[` +
+			`{"MessageId":"1","Body":"a","MessageAttributes":{"traceparent":{"DataType":"String","StringValue":` + strconv.Quote(traceparent) + `}}},` +
+			`{"MessageId":"2","Body":"b"}` +
+			`]}`))
+	}, opts...)
+
+	out, err := svc.ReceiveMessage(t.Context(), &sqs.ReceiveMessageInput{
+		QueueUrl:              aws.String("https://sqs.us-east-1.amazonaws.com/123456789012/queue"),
+		MessageAttributeNames: []string{"custom"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{"custom", "traceparent", "tracestate"}, body["MessageAttributeNames"])
+
+	want := trace.Link{
+		SpanContext: producerSC.WithRemote(true),
+		Attributes:  []attribute.KeyValue{attribute.String("messaging.message.id", "1")},
+	}
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	links := spans[0].Links()
+	require.Len(t, links, 1)
+	assert.Equal(t, want.SpanContext, links[0].SpanContext)
+	assert.Equal(t, want.Attributes, links[0].Attributes)
+
+	assert.Equal(t, []trace.Link{want}, SQSMessageLinks(t.Context(), out.Messages, opts...))
+
+	ctx := ExtractSQSMessage(t.Context(), out.Messages[0], opts...)
+	assert.Equal(t, producerSC.WithRemote(true), trace.SpanContextFromContext(ctx))
+}
+
+func TestInjectMessageAttributes(t *testing.T) {
+	m := otelMiddlewares{propagator: propagation.TraceContext{}}
+	ctx := trace.ContextWithSpanContext(t.Context(), producerSC)
+
+	full := make(map[string]snstypes.MessageAttributeValue, maxMessageAttributes)
+	for i := range maxMessageAttributes {
+		full[strconv.Itoa(i)] = snstypes.MessageAttributeValue{DataType: aws.String("String"), StringValue: aws.String("v")}
+	}
+
+	t.Run("SendMessageBatch", func(t *testing.T) {
+		in := &sqs.SendMessageBatchInput{
+			Entries: []sqstypes.SendMessageBatchRequestEntry{{Id: aws.String("a")}, {Id: aws.String("b")}},
+		}
+		got, ok := m.injectMessageAttributes(ctx, in).(*sqs.SendMessageBatchInput)
+		require.True(t, ok)
+		require.Len(t, got.Entries, 2)
+		for _, e := range got.Entries {
+			assert.Equal(t, traceparent, SQSMessageAttributeCarrier(e.MessageAttributes).Get("traceparent"))
+		}
+		for _, e := range in.Entries {
+			assert.Nil(t, e.MessageAttributes)
+		}
+	})
+
+	t.Run("Publish", func(t *testing.T) {
+		got, ok := m.injectMessageAttributes(ctx, &sns.PublishInput{}).(*sns.PublishInput)
+		require.True(t, ok)
+		assert.Equal(t, traceparent, SNSMessageAttributeCarrier(got.MessageAttributes).Get("traceparent"))
+	})
+
+	t.Run("PublishBatch", func(t *testing.T) {
+		in := &sns.PublishBatchInput{
+			PublishBatchRequestEntries: []snstypes.PublishBatchRequestEntry{
+				{Id: aws.String("a")},
+				{Id: aws.String("b"), MessageAttributes: full},
+			},
+		}
+		got, ok := m.injectMessageAttributes(ctx, in).(*sns.PublishBatchInput)
+		require.True(t, ok)
+		require.Len(t, got.PublishBatchRequestEntries, 2)
+		assert.Equal(t, traceparent, SNSMessageAttributeCarrier(got.PublishBatchRequestEntries[0].MessageAttributes).Get("traceparent"))
+		// The message attribute limit is not exceeded.
+		assert.Equal(t, full, got.PublishBatchRequestEntries[1].MessageAttributes)
+	})
+
+	t.Run("Other", func(t *testing.T) {
+		in := &sqs.DeleteQueueInput{}
+		assert.Same(t, in, m.injectMessageAttributes(ctx, in))
+	})
+}
@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+// S3AttributeBuilder sets S3 specific attributes depending on the S3 operation being performed.
+func S3AttributeBuilder(_ context.Context, in middleware.InitializeInput, _ middleware.InitializeOutput) []attribute.KeyValue {
+	var bucket, key, uploadID *string
+	var s3Attributes []attribute.KeyValue
+
+	switch v := in.Parameters.(type) {
+	case *s3.AbortMultipartUploadInput:
+		bucket, key, uploadID = v.Bucket, v.Key, v.UploadId
+	case *s3.CompleteMultipartUploadInput:
+		bucket, key, uploadID = v.Bucket, v.Key, v.UploadId
+	case *s3.CopyObjectInput:
+		bucket, key = v.Bucket, v.Key
+		if v.CopySource != nil {
+			s3Attributes = append(s3Attributes, semconv.AWSS3CopySource(*v.CopySource))
+		}
+	case *s3.CreateBucketInput:
+		bucket = v.Bucket
+	case *s3.CreateMultipartUploadInput:
+		bucket, key = v.Bucket, v.Key
+	case *s3.DeleteBucketInput:
+		bucket = v.Bucket
+	case *s3.DeleteObjectInput:
+		bucket, key = v.Bucket, v.Key
+	case *s3.DeleteObjectsInput:
+		bucket = v.Bucket
+	case *s3.GetObjectInput:
+		bucket, key = v.Bucket, v.Key
+	case *s3.HeadBucketInput:
+		bucket = v.Bucket
+	case *s3.HeadObjectInput:
+		bucket, key = v.Bucket, v.Key
+	case *s3.ListMultipartUploadsInput:
+		bucket = v.Bucket
+	case *s3.ListObjectsInput:
+		bucket = v.Bucket
+	case *s3.ListObjectsV2Input:
+		bucket = v.Bucket
+	case *s3.ListPartsInput:
+		bucket, key, uploadID = v.Bucket, v.Key, v.UploadId
+	case *s3.PutObjectInput:
+		bucket, key = v.Bucket, v.Key
+	case *s3.UploadPartInput:
+		bucket, key, uploadID = v.Bucket, v.Key, v.UploadId
+		if v.PartNumber != nil {
+			s3Attributes = append(s3Attributes, semconv.AWSS3PartNumber(int(*v.PartNumber)))
+		}
+	case *s3.UploadPartCopyInput:
+		bucket, key, uploadID = v.Bucket, v.Key, v.UploadId
+		if v.CopySource != nil {
+			s3Attributes = append(s3Attributes, semconv.AWSS3CopySource(*v.CopySource))
+		}
+		if v.PartNumber != nil {
+			s3Attributes = append(s3Attributes, semconv.AWSS3PartNumber(int(*v.PartNumber)))
+		}
+	}
+
+	if bucket != nil {
+		s3Attributes = append(s3Attributes, semconv.AWSS3Bucket(*bucket))
+	}
+	if key != nil {
+		s3Attributes = append(s3Attributes, semconv.AWSS3Key(*key))
+	}
+	if uploadID != nil {
+		s3Attributes = append(s3Attributes, semconv.AWSS3UploadID(*uploadID))
+	}
+
+	return s3Attributes
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelaws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+)
+
+func TestS3GetObjectInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.GetObjectInput{
+			Bucket: aws.String("bucket"),
+			Key:    aws.String("path/to/key"),
+		},
+	}
+
+	attributes := S3AttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		semconv.AWSS3Bucket("bucket"),
+		semconv.AWSS3Key("path/to/key"),
+	}, attributes)
+}
+
+func TestS3ListObjectsV2Input(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.ListObjectsV2Input{
+			Bucket: aws.String("bucket"),
+		},
+	}
+
+	attributes := S3AttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.ElementsMatch(t, []attribute.KeyValue{semconv.AWSS3Bucket("bucket")}, attributes)
+}
+
+func TestS3UploadPartCopyInput(t *testing.T) {
+	input := middleware.InitializeInput{
+		Parameters: &s3.UploadPartCopyInput{
+			Bucket:     aws.String("bucket"),
+			Key:        aws.String("key"),
+			CopySource: aws.String("source-bucket/source-key"),
+			PartNumber: aws.Int32(3),
+			UploadId:   aws.String("upload-id"),
+		},
+	}
+
+	attributes := S3AttributeBuilder(t.Context(), input, middleware.InitializeOutput{})
+
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		semconv.AWSS3Bucket("bucket"),
+		semconv.AWSS3Key("key"),
+		semconv.AWSS3CopySource("source-bucket/source-key"),
+		semconv.AWSS3PartNumber(3),
+		semconv.AWSS3UploadID("upload-id"),
+	}, attributes)
+}
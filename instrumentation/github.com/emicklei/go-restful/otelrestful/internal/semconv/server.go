@@ -234,6 +234,10 @@ func (HTTPServer) NetworkTransportAttr(network string) []attribute.KeyValue {
 type ServerMetricData struct {
 	ServerName   string
 	ResponseSize int64
+	// Streaming reports the request as a long-lived stream (e.g. an upgraded
+	// connection or a Server-Sent Events response). Its duration is not
+	// representative of request processing and is therefore not recorded.
+	Streaming bool
 
 	MetricData
 	MetricAttributes
@@ -269,7 +273,9 @@ func (n HTTPServer) RecordMetrics(ctx context.Context, md ServerMetricData) {
 	*recordOpts = append(*recordOpts, o)
 	n.requestBodySizeHistogram.Inst().Record(ctx, md.RequestSize, *recordOpts...)
 	n.responseBodySizeHistogram.Inst().Record(ctx, md.ResponseSize, *recordOpts...)
-	n.requestDurationHistogram.Inst().Record(ctx, durationToSeconds(md.RequestDuration), o)
+	if !md.Streaming {
+		n.requestDurationHistogram.Inst().Record(ctx, durationToSeconds(md.RequestDuration), o)
+	}
 	*recordOpts = (*recordOpts)[:0]
 	metricRecordOptionPool.Put(recordOpts)
 }
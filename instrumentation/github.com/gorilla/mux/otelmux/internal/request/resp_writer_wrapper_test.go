@@ -7,11 +7,14 @@
 package request
 
 import (
+	"bufio"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestRespWriterWriteHeader(t *testing.T) {
@@ -76,3 +79,117 @@ func TestConcurrentRespWriterWrapper(t *testing.T) {
 	assert.NotNil(t, rw.StatusCode())
 	assert.NoError(t, rw.Error())
 }
+
+func TestRespWriterStreamEventStream(t *testing.T) {
+	var got []int
+	rw := NewRespWriterWrapper(httptest.NewRecorder(), func(int64) {})
+	rw.OnStream = func(code int) { got = append(got, code) }
+
+	rw.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
+	_, _ = rw.Write([]byte("data: 1\n\n"))
+	_, _ = rw.Write([]byte("data: 2\n\n"))
+
+	assert.True(t, rw.Streaming())
+	assert.False(t, rw.Hijacked())
+	assert.Equal(t, []int{http.StatusOK}, got)
+}
+
+func TestRespWriterStreamSwitchingProtocols(t *testing.T) {
+	var got []int
+	rw := NewRespWriterWrapper(httptest.NewRecorder(), func(int64) {})
+	rw.OnStream = func(code int) { got = append(got, code) }
+
+	rw.WriteHeader(http.StatusSwitchingProtocols)
+
+	assert.True(t, rw.Streaming())
+	assert.Equal(t, []int{http.StatusSwitchingProtocols}, got)
+}
+
+func TestRespWriterNotStreaming(t *testing.T) {
+	rw := NewRespWriterWrapper(httptest.NewRecorder(), func(int64) {})
+	rw.OnStream = func(int) { t.Error("unexpected stream") }
+
+	rw.Header().Set("Content-Type", "application/json")
+	_, _ = rw.Write([]byte("{}"))
+
+	assert.False(t, rw.Streaming())
+}
+
+func TestRespWriterFlushCallback(t *testing.T) {
+	var flushes int
+	rw := NewRespWriterWrapper(httptest.NewRecorder(), func(int64) {})
+	rw.OnFlush = func() { flushes++ }
+
+	rw.Flush()
+	rw.Flush()
+	assert.Equal(t, 2, flushes)
+}
+
+type hijackableResponseWriter struct {
+	nonFlushableResponseWriter
+	err error
+}
+
+func (w hijackableResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, w.err
+}
+
+func TestRespWriterHijack(t *testing.T) {
+	tests := []struct {
+		name       string
+		upgrade    bool
+		header     int
+		wantStatus int
+	}{
+		{
+			name:       "upgrade",
+			upgrade:    true,
+			wantStatus: http.StatusSwitchingProtocols,
+		},
+		{
+			name: "no upgrade",
+		},
+		{
+			name:       "header written",
+			upgrade:    true,
+			header:     http.StatusOK,
+			wantStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			rw := NewRespWriterWrapper(hijackableResponseWriter{}, func(int64) {})
+			rw.Upgrade = tt.upgrade
+			rw.OnStream = func(code int) { got = append(got, code) }
+
+			if tt.header != 0 {
+				rw.WriteHeader(tt.header)
+			}
+			_, _, err := rw.Hijack()
+			require.NoError(t, err)
+			assert.True(t, rw.Hijacked())
+			assert.True(t, rw.Streaming())
+			assert.Equal(t, []int{tt.wantStatus}, got)
+			assert.Equal(t, tt.wantStatus, rw.StatusCode())
+		})
+	}
+}
+
+func TestRespWriterHijackError(t *testing.T) {
+	rw := NewRespWriterWrapper(hijackableResponseWriter{err: assert.AnError}, func(int64) {})
+
+	_, _, err := rw.Hijack()
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, rw.Hijacked())
+	assert.False(t, rw.Streaming())
+}
+
+func TestRespWriterHijackNotSupported(t *testing.T) {
+	rw := NewRespWriterWrapper(nonFlushableResponseWriter{}, func(int64) {})
+
+	_, _, err := rw.Hijack()
+	assert.ErrorIs(t, err, http.ErrNotSupported)
+	assert.False(t, rw.Hijacked())
+}
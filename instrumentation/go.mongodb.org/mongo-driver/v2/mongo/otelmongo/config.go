@@ -22,6 +22,8 @@ type config struct {
 	Tracer trace.Tracer
 
 	CommandAttributeDisabled bool
+	QueryTextMode            QueryTextMode
+	QueryTextMaxLength       int
 
 	SpanNameFormatter SpanNameFormatterFunc
 }
@@ -114,3 +116,40 @@ func WithCommandAttributeDisabled(disabled bool) Option {
 		cfg.CommandAttributeDisabled = disabled
 	})
 }
+
+// QueryTextMode specifies how the MongoDB command is recorded in the
+// db.query.text attribute.
+type QueryTextMode int
+
+const (
+	// QueryTextRaw records the command as Extended JSON, including the
+	// values it contains. This is the default.
+	QueryTextRaw QueryTextMode = iota
+	// QueryTextSanitized records the command as JSON with every literal
+	// value replaced by ?. Field names, operators and the structure of
+	// documents and arrays are kept, as well as the collection the command
+	// operates on.
+	//
+	// For example, {"find": "users", "filter": {"age": {"$gt": 21}}} is
+	// recorded as {"find":"users","filter":{"age":{"$gt":?}}}.
+	QueryTextSanitized
+)
+
+// WithQueryTextMode specifies how the MongoDB command is recorded in the
+// db.query.text attribute when it is enabled with
+// [WithCommandAttributeDisabled]. If none is specified, [QueryTextRaw] is
+// used.
+func WithQueryTextMode(mode QueryTextMode) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.QueryTextMode = mode
+	})
+}
+
+// WithQueryTextMaxLength specifies the maximum length in bytes of the
+// db.query.text attribute. Longer values are truncated. If none is
+// specified, or n is not positive, the value is not truncated.
+func WithQueryTextMaxLength(n int) Option {
+	return optionFunc(func(cfg *config) {
+		cfg.QueryTextMaxLength = n
+	})
+}
@@ -4,7 +4,8 @@
 // Package otelmongo instruments go.mongodb.org/mongo-driver/v2/mongo.
 //
 // `NewMonitor` will return an event.CommandMonitor which is used to trace
-// requests and collect its metrics.
+// requests and collect its metrics. `NewPoolMonitor` will return an
+// event.PoolMonitor which is used to collect the connection pool metrics.
 //
 // This code was originally based on the following:
 // - https://github.com/open-telemetry/opentelemetry-go-contrib/tree/323e373a6c15ae310bdd0617e3ed52d8cb8e4e6f/instrumentation/go.mongodb.org/mongo-driver/mongo/otelmongo
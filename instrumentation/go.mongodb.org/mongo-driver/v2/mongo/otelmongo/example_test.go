@@ -48,6 +48,24 @@ func Example() {
 	}
 }
 
+func ExampleNewPoolMonitor() {
+	// connect to MongoDB
+	opts := options.Client()
+	opts.Monitor = otelmongo.NewMonitor()
+	opts.PoolMonitor = otelmongo.NewPoolMonitor()
+	opts.ApplyURI("mongodb://localhost:27017")
+	client, err := mongo.Connect(opts)
+	if err != nil {
+		panic(err)
+	}
+
+	defer func() {
+		if err := client.Disconnect(context.TODO()); err != nil {
+			panic(err)
+		}
+	}()
+}
+
 func ExampleWithSpanNameFormatter() {
 	// connect to MongoDB
 	opts := options.Client()
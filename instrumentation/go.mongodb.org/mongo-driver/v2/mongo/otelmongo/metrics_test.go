@@ -11,9 +11,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/event"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.mongodb.org/mongo-driver/v2/x/mongo/driver/drivertest"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 )
@@ -143,3 +145,92 @@ func TestNewMonitorWithInvalidMeterProvider(t *testing.T) {
 	assert.NotNil(t, monitor.Succeeded)
 	assert.NotNil(t, monitor.Failed)
 }
+
+func TestMetricsConnectionPool(t *testing.T) {
+	reader := metric.NewManualReader()
+	provider := metric.NewMeterProvider(metric.WithReader(reader))
+
+	m := newPoolMonitor(newConfig(WithMeterProvider(provider)))
+	now := time.Unix(100, 0)
+	m.now = func() time.Time { return now }
+
+	const addr = "localhost:27017"
+	for _, evt := range []*event.PoolEvent{
+		{Type: event.ConnectionPoolCreated, Address: addr, PoolOptions: &event.MonitorPoolOptions{MaxPoolSize: 2}},
+		{Type: event.ConnectionCreated, Address: addr, ConnectionID: 1},
+		{Type: event.ConnectionReady, Address: addr, ConnectionID: 1, Duration: 20 * time.Millisecond},
+		{Type: event.ConnectionCreated, Address: addr, ConnectionID: 2},
+		{Type: event.ConnectionReady, Address: addr, ConnectionID: 2, Duration: 30 * time.Millisecond},
+		{Type: event.ConnectionCheckOutStarted, Address: addr},
+		{Type: event.ConnectionCheckedOut, Address: addr, ConnectionID: 1, Duration: 2 * time.Millisecond},
+		{Type: event.ConnectionCheckOutStarted, Address: addr},
+		{Type: event.ConnectionCheckedOut, Address: addr, ConnectionID: 2, Duration: 3 * time.Millisecond},
+		{Type: event.ConnectionCheckOutStarted, Address: addr},
+		{Type: event.ConnectionCheckOutStarted, Address: addr},
+		{Type: event.ConnectionCheckOutFailed, Address: addr, Duration: time.Second, Reason: event.ReasonTimedOut},
+	} {
+		m.Event(evt)
+	}
+	now = now.Add(4 * time.Second)
+	m.Event(&event.PoolEvent{Type: event.ConnectionCheckedIn, Address: addr, ConnectionID: 1})
+	// Closing a used connection.
+	m.Event(&event.PoolEvent{Type: event.ConnectionClosed, Address: addr, ConnectionID: 2, Reason: event.ReasonError})
+	// Events of unknown connections are ignored.
+	m.Event(&event.PoolEvent{Type: event.ConnectionCheckedIn, Address: addr, ConnectionID: 3})
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	poolName := attribute.String("db.client.connection.pool.name", addr)
+	sums := func(name string) map[string]int64 {
+		t.Helper()
+		sum, ok := metrics[name].(metricdata.Sum[int64])
+		require.True(t, ok, name)
+		got := make(map[string]int64)
+		for _, dp := range sum.DataPoints {
+			v, _ := dp.Attributes.Value(poolName.Key)
+			assert.Equal(t, addr, v.AsString(), name)
+			state, _ := dp.Attributes.Value("db.client.connection.state")
+			got[state.AsString()] = dp.Value
+		}
+		return got
+	}
+	histogram := func(name string) (uint64, float64) {
+		t.Helper()
+		h, ok := metrics[name].(metricdata.Histogram[float64])
+		require.True(t, ok, name)
+		require.Len(t, h.DataPoints, 1, name)
+		return h.DataPoints[0].Count, h.DataPoints[0].Sum
+	}
+
+	assert.Equal(t, map[string]int64{"idle": 1, "used": 0}, sums("db.client.connection.count"))
+	assert.Equal(t, map[string]int64{"": 2}, sums("db.client.connection.max"))
+	assert.Equal(t, map[string]int64{"": 1}, sums("db.client.connection.pending_requests"))
+	assert.Equal(t, map[string]int64{"": 1}, sums("db.client.connection.timeouts"))
+
+	count, sum := histogram("db.client.connection.create_time")
+	assert.Equal(t, uint64(2), count)
+	assert.InDelta(t, 0.05, sum, 1e-9)
+
+	count, sum = histogram("db.client.connection.wait_time")
+	assert.Equal(t, uint64(3), count)
+	assert.InDelta(t, 1.005, sum, 1e-9)
+
+	count, sum = histogram("db.client.connection.use_time")
+	assert.Equal(t, uint64(1), count)
+	assert.InDelta(t, 4.0, sum, 1e-9)
+
+	m.Event(&event.PoolEvent{Type: event.ConnectionPoolClosed, Address: addr})
+	rm = metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+	assert.Equal(t, map[string]int64{"": 0}, sums("db.client.connection.max"))
+}
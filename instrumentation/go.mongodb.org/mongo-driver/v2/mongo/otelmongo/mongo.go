@@ -47,13 +47,14 @@ func (m *monitor) Started(ctx context.Context, evt *event.CommandStartedEvent) {
 		semconv.NetworkTransportTCP,
 	}
 	if !m.cfg.CommandAttributeDisabled {
-		attrs = append(attrs, semconv.DBQueryText(sanitizeCommand(evt.Command)))
+		attrs = append(attrs, semconv.DBQueryText(m.cfg.queryText(evt.Command)))
 	}
 
 	collection, err := extractCollection(evt)
 	if err == nil && collection != "" {
 		attrs = append(attrs, semconv.DBCollectionName(collection))
 	}
+	attrs = append(attrs, semconv.DBQuerySummary(querySummary(evt.CommandName, collection)))
 
 	spanName := m.cfg.SpanNameFormatter(evt)
 
@@ -94,7 +95,7 @@ func (m *monitor) Succeeded(ctx context.Context, evt *event.CommandSucceededEven
 	// TODO: db.query.text attribute is currently disabled by default.
 	// Because event does not provide the query text directly.
 	// command := m.extractCommand(evt)
-	// attrs = append(attrs, semconv.DBQueryText(sanitizeCommand(evt.Command)))
+	// attrs = append(attrs, semconv.DBQueryText(m.cfg.queryText(evt.Command)))
 
 	m.ClientOperationDuration.RecordSet(
 		ctx,
@@ -124,7 +125,7 @@ func (m *monitor) Failed(ctx context.Context, evt *event.CommandFailedEvent) {
 	// TODO: db.query.text attribute is currently disabled by default.
 	// Because event does not provide the query text directly.
 	// command := m.extractCommand(evt)
-	// attrs = append(attrs, semconv.DBQueryText(sanitizeCommand(evt.Command)))
+	// attrs = append(attrs, semconv.DBQueryText(m.cfg.queryText(evt.Command)))
 
 	m.ClientOperationDuration.RecordSet(
 		ctx,
@@ -155,13 +156,6 @@ func (m *monitor) Finished(evt *event.CommandFinishedEvent, err error) {
 	span.End()
 }
 
-// TODO sanitize values where possible, then re-enable `db.statement` span attributes default.
-// TODO limit maximum size.
-func sanitizeCommand(command bson.Raw) string {
-	b, _ := bson.MarshalExtJSON(command, false, false)
-	return string(b)
-}
-
 // extractCollection extracts the collection for the given mongodb command event.
 // For CRUD operations, this is the first key/value string pair in the bson
 // document where key == "<operation>" (e.g. key == "insert").
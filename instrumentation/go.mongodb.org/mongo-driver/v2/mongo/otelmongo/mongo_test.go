@@ -104,6 +104,9 @@ func TestDBCrudOperation(t *testing.T) {
 		func(s sdktrace.ReadOnlySpan) bool {
 			return assert.Contains(t, s.Attributes(), attribute.String("db.collection.name", "test-collection"))
 		},
+		func(s sdktrace.ReadOnlySpan) bool {
+			return assert.Contains(t, s.Attributes(), attribute.String("db.query.summary", "insert test-collection"))
+		},
 		func(s sdktrace.ReadOnlySpan) bool {
 			return assert.Equal(t, codes.Unset, s.Status().Code)
 		},
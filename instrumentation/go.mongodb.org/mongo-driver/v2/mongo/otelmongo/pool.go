@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelmongo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/event"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/dbconv"
+)
+
+// connKey identifies a connection of a pool.
+type connKey struct {
+	Address      string
+	ConnectionID int64
+}
+
+// connState is the state of a pooled connection. The zero value is a
+// connection that is created but not yet ready.
+type connState struct {
+	ready      bool
+	checkedOut time.Time
+}
+
+type poolMonitor struct {
+	ConnectionCount           dbconv.ClientConnectionCount
+	ConnectionMax             dbconv.ClientConnectionMax
+	ConnectionCreateTime      dbconv.ClientConnectionCreateTime
+	ConnectionWaitTime        dbconv.ClientConnectionWaitTime
+	ConnectionUseTime         dbconv.ClientConnectionUseTime
+	ConnectionPendingRequests dbconv.ClientConnectionPendingRequests
+	ConnectionTimeouts        dbconv.ClientConnectionTimeouts
+
+	sync.Mutex
+	conns map[connKey]*connState
+	// maxSize holds the maximum size of the open pools by address.
+	maxSize map[string]int64
+
+	now func() time.Time
+}
+
+// Event records the metrics of a single connection pool event. The pool
+// name of all metrics is the address of the server the pool connects to.
+func (m *poolMonitor) Event(evt *event.PoolEvent) {
+	// Pool events do not carry a context.
+	ctx := context.Background()
+	pool := evt.Address
+
+	switch evt.Type {
+	case event.ConnectionPoolCreated:
+		if evt.PoolOptions == nil || evt.PoolOptions.MaxPoolSize == 0 {
+			// A MaxPoolSize of 0 means the pool size is unlimited.
+			return
+		}
+		n := int64(evt.PoolOptions.MaxPoolSize) //nolint:gosec // Pool sizes do not overflow int64.
+		m.Lock()
+		m.maxSize[pool] = n
+		m.Unlock()
+		m.ConnectionMax.Add(ctx, n, pool)
+	case event.ConnectionPoolClosed:
+		m.Lock()
+		n, ok := m.maxSize[pool]
+		delete(m.maxSize, pool)
+		m.Unlock()
+		if ok {
+			m.ConnectionMax.Add(ctx, -n, pool)
+		}
+	case event.ConnectionCreated:
+		m.Lock()
+		m.conns[connKey{pool, evt.ConnectionID}] = &connState{}
+		m.Unlock()
+	case event.ConnectionReady:
+		m.Lock()
+		c, ok := m.conns[connKey{pool, evt.ConnectionID}]
+		if ok {
+			c.ready = true
+		}
+		m.Unlock()
+		if !ok {
+			return
+		}
+		m.ConnectionCreateTime.Record(ctx, evt.Duration.Seconds(), pool)
+		m.ConnectionCount.Add(ctx, 1, pool, dbconv.ClientConnectionStateIdle)
+	case event.ConnectionClosed:
+		key := connKey{pool, evt.ConnectionID}
+		m.Lock()
+		c, ok := m.conns[key]
+		delete(m.conns, key)
+		m.Unlock()
+		if !ok || !c.ready {
+			return
+		}
+		state := dbconv.ClientConnectionStateIdle
+		if !c.checkedOut.IsZero() {
+			state = dbconv.ClientConnectionStateUsed
+		}
+		m.ConnectionCount.Add(ctx, -1, pool, state)
+	case event.ConnectionCheckOutStarted:
+		m.ConnectionPendingRequests.Add(ctx, 1, pool)
+	case event.ConnectionCheckOutFailed:
+		m.ConnectionPendingRequests.Add(ctx, -1, pool)
+		m.ConnectionWaitTime.Record(ctx, evt.Duration.Seconds(), pool)
+		if evt.Reason == event.ReasonTimedOut {
+			m.ConnectionTimeouts.Add(ctx, 1, pool)
+		}
+	case event.ConnectionCheckedOut:
+		m.ConnectionPendingRequests.Add(ctx, -1, pool)
+		m.ConnectionWaitTime.Record(ctx, evt.Duration.Seconds(), pool)
+
+		m.Lock()
+		c, ok := m.conns[connKey{pool, evt.ConnectionID}]
+		if ok {
+			c.checkedOut = m.now()
+		}
+		m.Unlock()
+		if !ok {
+			return
+		}
+		m.ConnectionCount.Add(ctx, -1, pool, dbconv.ClientConnectionStateIdle)
+		m.ConnectionCount.Add(ctx, 1, pool, dbconv.ClientConnectionStateUsed)
+	case event.ConnectionCheckedIn:
+		m.Lock()
+		c, ok := m.conns[connKey{pool, evt.ConnectionID}]
+		var checkedOut time.Time
+		if ok {
+			checkedOut, c.checkedOut = c.checkedOut, time.Time{}
+		}
+		m.Unlock()
+		if !ok || checkedOut.IsZero() {
+			return
+		}
+		m.ConnectionUseTime.Record(ctx, m.now().Sub(checkedOut).Seconds(), pool)
+		m.ConnectionCount.Add(ctx, -1, pool, dbconv.ClientConnectionStateUsed)
+		m.ConnectionCount.Add(ctx, 1, pool, dbconv.ClientConnectionStateIdle)
+	}
+}
+
+// NewPoolMonitor creates a new mongodb event PoolMonitor that records the
+// connection pool metrics of the client it is set on:
+//   - db.client.connection.count
+//   - db.client.connection.max
+//   - db.client.connection.create_time
+//   - db.client.connection.wait_time
+//   - db.client.connection.use_time
+//   - db.client.connection.pending_requests
+//   - db.client.connection.timeouts
+//
+// It is used alongside the CommandMonitor returned by [NewMonitor]. The
+// db.client.connection.pool.name attribute is the address of the server the
+// pool connects to.
+func NewPoolMonitor(opts ...Option) *event.PoolMonitor {
+	m := newPoolMonitor(newConfig(opts...))
+	return &event.PoolMonitor{Event: m.Event}
+}
+
+func newPoolMonitor(cfg config) *poolMonitor {
+	m := &poolMonitor{
+		conns:   make(map[connKey]*connState),
+		maxSize: make(map[string]int64),
+		now:     time.Now,
+	}
+
+	var err error
+	m.ConnectionCount, err = dbconv.NewClientConnectionCount(cfg.Meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.ConnectionMax, err = dbconv.NewClientConnectionMax(cfg.Meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	buckets := metric.WithExplicitBucketBoundaries(0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5, 10)
+	m.ConnectionCreateTime, err = dbconv.NewClientConnectionCreateTime(cfg.Meter, buckets)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.ConnectionWaitTime, err = dbconv.NewClientConnectionWaitTime(cfg.Meter, buckets)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.ConnectionUseTime, err = dbconv.NewClientConnectionUseTime(cfg.Meter, buckets)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.ConnectionPendingRequests, err = dbconv.NewClientConnectionPendingRequests(cfg.Meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	m.ConnectionTimeouts, err = dbconv.NewClientConnectionTimeouts(cfg.Meter)
+	if err != nil {
+		otel.Handle(err)
+	}
+	return m
+}
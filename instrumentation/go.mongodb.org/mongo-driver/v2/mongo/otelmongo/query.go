@@ -0,0 +1,120 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelmongo
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode/utf8"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// queryText returns the db.query.text attribute value of command according
+// to the configured QueryTextMode and maximum length.
+func (c config) queryText(command bson.Raw) string {
+	var text string
+	switch c.QueryTextMode {
+	case QueryTextSanitized:
+		text = sanitizeCommand(command)
+	default:
+		b, _ := bson.MarshalExtJSON(command, false, false)
+		text = string(b)
+	}
+	return truncate(text, c.QueryTextMaxLength)
+}
+
+// sanitizeCommand returns command as JSON with all literal values replaced
+// by ?. The value of the first element, which holds the collection name for
+// CRUD commands, is kept if it is a string.
+func sanitizeCommand(command bson.Raw) string {
+	var b strings.Builder
+	elems, err := command.Elements()
+	if err != nil {
+		return ""
+	}
+
+	b.WriteByte('{')
+	for i, elem := range elems {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		writeKey(&b, elem.Key())
+		v := elem.Value()
+		if i == 0 && v.Type == bson.TypeString {
+			writeString(&b, v.StringValue())
+			continue
+		}
+		writeSanitized(&b, v)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func writeSanitized(b *strings.Builder, v bson.RawValue) {
+	switch v.Type {
+	case bson.TypeEmbeddedDocument:
+		elems, err := v.Document().Elements()
+		if err != nil {
+			b.WriteByte('?')
+			return
+		}
+		b.WriteByte('{')
+		for i, elem := range elems {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeKey(b, elem.Key())
+			writeSanitized(b, elem.Value())
+		}
+		b.WriteByte('}')
+	case bson.TypeArray:
+		values, err := v.Array().Values()
+		if err != nil {
+			b.WriteByte('?')
+			return
+		}
+		b.WriteByte('[')
+		for i, value := range values {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			writeSanitized(b, value)
+		}
+		b.WriteByte(']')
+	default:
+		b.WriteByte('?')
+	}
+}
+
+func writeKey(b *strings.Builder, key string) {
+	writeString(b, key)
+	b.WriteByte(':')
+}
+
+func writeString(b *strings.Builder, s string) {
+	q, _ := json.Marshal(s)
+	b.Write(q)
+}
+
+// truncate returns s truncated to at most n bytes without splitting a UTF-8
+// encoded rune. If n is not positive, s is returned unchanged.
+func truncate(s string, n int) string {
+	if n <= 0 || len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// querySummary returns the db.query.summary attribute value of a command:
+// the command name followed by the collection it operates on, if any.
+func querySummary(commandName, collection string) string {
+	if collection == "" {
+		return commandName
+	}
+	return commandName + " " + collection
+}
@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelmongo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestSanitizeCommand(t *testing.T) {
+	tests := []struct {
+		name    string
+		command bson.D
+		want    string
+	}{
+		{
+			name: "find",
+			command: bson.D{
+				{Key: "find", Value: "users"},
+				{Key: "filter", Value: bson.D{
+					{Key: "name", Value: "alice"},
+					{Key: "age", Value: bson.D{{Key: "$gt", Value: 21}}},
+					{Key: "role", Value: bson.D{{Key: "$in", Value: bson.A{"admin", "owner"}}}},
+				}},
+				{Key: "limit", Value: 10},
+				{Key: "$db", Value: "app"},
+			},
+			want: `{"find":"users","filter":{"name":?,"age":{"$gt":?},"role":{"$in":[?,?]}},"limit":?,"$db":?}`,
+		},
+		{
+			name: "aggregate",
+			command: bson.D{
+				{Key: "aggregate", Value: "orders"},
+				{Key: "pipeline", Value: bson.A{
+					bson.D{{Key: "$match", Value: bson.D{{Key: "status", Value: "shipped"}}}},
+					bson.D{{Key: "$group", Value: bson.D{
+						{Key: "_id", Value: "$customer"},
+						{Key: "total", Value: bson.D{{Key: "$sum", Value: "$amount"}}},
+					}}},
+				}},
+				{Key: "cursor", Value: bson.D{}},
+			},
+			want: `{"aggregate":"orders","pipeline":[{"$match":{"status":?}},{"$group":{"_id":?,"total":{"$sum":?}}}],"cursor":{}}`,
+		},
+		{
+			name: "aggregate database",
+			command: bson.D{
+				{Key: "aggregate", Value: 1},
+				{Key: "pipeline", Value: bson.A{bson.D{{Key: "$currentOp", Value: bson.D{}}}}},
+			},
+			want: `{"aggregate":?,"pipeline":[{"$currentOp":{}}]}`,
+		},
+		{
+			name: "update",
+			command: bson.D{
+				{Key: "update", Value: "users"},
+				{Key: "updates", Value: bson.A{
+					bson.D{
+						{Key: "q", Value: bson.D{{Key: "_id", Value: bson.NewObjectID()}}},
+						{Key: "u", Value: bson.D{{Key: "$set", Value: bson.D{{Key: "email", Value: "alice@example.com"}}}}},
+						{Key: "upsert", Value: true},
+					},
+				}},
+			},
+			want: `{"update":"users","updates":[{"q":{"_id":?},"u":{"$set":{"email":?}},"upsert":?}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, err := bson.Marshal(tt.command)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, sanitizeCommand(command))
+		})
+	}
+}
+
+func TestQueryText(t *testing.T) {
+	command, err := bson.Marshal(bson.D{
+		{Key: "find", Value: "users"},
+		{Key: "filter", Value: bson.D{{Key: "name", Value: "alice"}}},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "default",
+			want: `{"find":"users","filter":{"name":"alice"}}`,
+		},
+		{
+			name: "sanitized",
+			opts: []Option{WithQueryTextMode(QueryTextSanitized)},
+			want: `{"find":"users","filter":{"name":?}}`,
+		},
+		{
+			name: "max length",
+			opts: []Option{WithQueryTextMaxLength(16)},
+			want: `{"find":"users",`,
+		},
+		{
+			name: "sanitized max length",
+			opts: []Option{WithQueryTextMode(QueryTextSanitized), WithQueryTextMaxLength(1000)},
+			want: `{"find":"users","filter":{"name":?}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newConfig(tt.opts...).queryText(command))
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "abc", truncate("abc", 0))
+	assert.Equal(t, "abc", truncate("abc", 3))
+	assert.Equal(t, "ab", truncate("abc", 2))
+	// "é" is encoded on two bytes and is not split.
+	assert.Equal(t, "a", truncate("aé", 2))
+	assert.Equal(t, "aé", truncate("aé", 3))
+}
+
+func TestQuerySummary(t *testing.T) {
+	assert.Equal(t, "find users", querySummary("find", "users"))
+	assert.Equal(t, "listCollections", querySummary("listCollections", ""))
+}
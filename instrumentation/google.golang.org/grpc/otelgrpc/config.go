@@ -7,6 +7,7 @@ import (
 	"context"
 	"os"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -57,6 +58,18 @@ type config struct {
 	ReceivedEvent bool
 	SentEvent     bool
 
+	MessageEventSampling   float64
+	MaxMessageEvents       int
+	StreamSummary          bool
+	StreamProgressInterval time.Duration
+
+	MessageMetrics bool
+
+	RequestMetadata       []string
+	ResponseMetadata      []string
+	MetricRequestMetadata []string
+	RedactedMetadata      map[string]struct{}
+
 	semconvMode semconvMode
 }
 
@@ -74,10 +87,11 @@ func (f optionFunc) apply(c *config) {
 // newConfig returns a config configured with all the passed Options.
 func newConfig(opts []Option) *config {
 	c := &config{
-		Propagators:    otel.GetTextMapPropagator(),
-		TracerProvider: otel.GetTracerProvider(),
-		MeterProvider:  otel.GetMeterProvider(),
-		semconvMode:    parseSemconvMode(),
+		Propagators:      otel.GetTextMapPropagator(),
+		TracerProvider:   otel.GetTracerProvider(),
+		MeterProvider:    otel.GetMeterProvider(),
+		MaxMessageEvents: defaultMaxMessageEvents,
+		semconvMode:      parseSemconvMode(),
 	}
 	for _, o := range opts {
 		o.apply(c)
@@ -190,6 +204,10 @@ const (
 // Valid events are:
 //   - ReceivedEvents: Record the number of bytes read after every gRPC read operation.
 //   - SentEvents: Record the number of bytes written after every gRPC write operation.
+//
+// Message events are not part of the RPC semantic conventions since v1.40.0
+// and are only recorded if a sampling rate is set with
+// [WithMessageEventSampling].
 func WithMessageEvents(events ...Event) Option {
 	return optionFunc(func(c *config) {
 		for _, e := range events {
@@ -203,6 +221,142 @@ func WithMessageEvents(events ...Event) Option {
 	})
 }
 
+// defaultMaxMessageEvents is the default maximum number of message events
+// recorded on the span of an RPC.
+const defaultMaxMessageEvents = 128
+
+// WithMessageEventSampling returns an Option that records the message events
+// selected with [WithMessageEvents] for a fraction rate of the messages of
+// each RPC. The first message sent and received is always recorded, followed
+// by one message every 1/rate messages. A rate greater than or equal to 1
+// records every message. A rate less than or equal to 0, the default,
+// disables message events.
+//
+// The number of message events recorded on the span of an RPC is limited by
+// [WithMaxMessageEvents].
+func WithMessageEventSampling(rate float64) Option {
+	return optionFunc(func(c *config) {
+		c.MessageEventSampling = min(rate, 1)
+	})
+}
+
+// WithMaxMessageEvents returns an Option that limits the number of message
+// events recorded on the span of an RPC to n. Message events of an RPC past
+// the limit are dropped. If this option is not provided, or n is less than or
+// equal to 0, the limit is 128.
+func WithMaxMessageEvents(n int) Option {
+	return optionFunc(func(c *config) {
+		if n > 0 {
+			c.MaxMessageEvents = n
+		}
+	})
+}
+
+// WithStreamSummary returns an Option that adds the total number of messages
+// and bytes received and sent by a streaming RPC as attributes of its span
+// when the RPC ends. The attributes are [ReceivedMessagesKey],
+// [SentMessagesKey], [ReceivedBytesKey] and [SentBytesKey]. Byte counts are
+// uncompressed message sizes.
+func WithStreamSummary() Option {
+	return optionFunc(func(c *config) {
+		c.StreamSummary = true
+	})
+}
+
+// WithStreamProgressEvents returns an Option that adds a "progress" event to
+// the span of a streaming RPC at most once every interval. Events are
+// recorded when messages are exchanged and carry the running totals of
+// messages and bytes received and sent (see [WithStreamSummary]). This
+// reports the activity of streams that last longer than the spans can be
+// observed for. A non-positive interval disables progress events, the
+// default.
+func WithStreamProgressEvents(interval time.Duration) Option {
+	return optionFunc(func(c *config) {
+		c.StreamProgressInterval = interval
+	})
+}
+
+// WithMessageMetrics returns an Option that configures the handlers to record
+// the size and number of messages exchanged in each RPC.
+//
+// The following histograms are recorded by [NewServerHandler]:
+//   - rpc.server.request.size
+//   - rpc.server.response.size
+//   - rpc.server.requests_per_rpc
+//   - rpc.server.responses_per_rpc
+//
+// The following histograms are recorded by [NewClientHandler]:
+//   - rpc.client.request.size
+//   - rpc.client.response.size
+//   - rpc.client.requests_per_rpc
+//   - rpc.client.responses_per_rpc
+//
+// These histograms are only defined by the v1.37 semantic conventions. They
+// are recorded when the OTEL_SEMCONV_STABILITY_OPT_IN environment variable is
+// set to rpc/old or rpc/dup, with the v1.37 attributes (rpc.system,
+// rpc.service and rpc.method), and this option has no effect otherwise.
+//
+// The sizes are the uncompressed message sizes. The number of messages per
+// RPC is recorded when the RPC ends and also carries the
+// rpc.grpc.status_code attribute.
+func WithMessageMetrics() Option {
+	return optionFunc(func(c *config) {
+		c.MessageMetrics = true
+	})
+}
+
+// WithRequestMetadata returns an Option that records the values of the
+// request metadata keys as span attributes. The server handler reads the
+// metadata received from the client, and the client handler the metadata
+// sent with the RPC context.
+//
+// Keys are case-insensitive. The attributes are rpc.request.metadata.<key>
+// or, with the OTEL_SEMCONV_STABILITY_OPT_IN environment variable set to
+// rpc/old, rpc.grpc.request.metadata.<key> (both with rpc/dup).
+func WithRequestMetadata(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.RequestMetadata = append(c.RequestMetadata, normalizeMetadataKeys(keys)...)
+	})
+}
+
+// WithResponseMetadata returns an Option that records the values of the
+// response metadata keys, from both the header and the trailer, as span
+// attributes.
+//
+// Keys are case-insensitive. The attributes are rpc.response.metadata.<key>
+// or, with the OTEL_SEMCONV_STABILITY_OPT_IN environment variable set to
+// rpc/old, rpc.grpc.response.metadata.<key> (both with rpc/dup).
+func WithResponseMetadata(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.ResponseMetadata = append(c.ResponseMetadata, normalizeMetadataKeys(keys)...)
+	})
+}
+
+// WithMetricRequestMetadata returns an Option that adds the values of the
+// request metadata keys as attributes to all metrics recorded by the handler,
+// see [WithRequestMetadata]. Every distinct value creates a new time series:
+// only use keys with a small, bounded set of values.
+func WithMetricRequestMetadata(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		c.MetricRequestMetadata = append(c.MetricRequestMetadata, normalizeMetadataKeys(keys)...)
+	})
+}
+
+// WithRedactedMetadata returns an Option that replaces the values of the
+// metadata keys recorded by [WithRequestMetadata], [WithResponseMetadata] and
+// [WithMetricRequestMetadata] with "REDACTED". This records the presence of
+// sensitive metadata without its value.
+func WithRedactedMetadata(keys ...string) Option {
+	return optionFunc(func(c *config) {
+		if c.RedactedMetadata == nil {
+			c.RedactedMetadata = make(map[string]struct{}, len(keys))
+		}
+		for _, k := range normalizeMetadataKeys(keys) {
+			c.RedactedMetadata[k] = struct{}{}
+		}
+	})
+}
+
 // WithSpanKind returns an Option to set the span kind for spans created by
 // the handler.
 //
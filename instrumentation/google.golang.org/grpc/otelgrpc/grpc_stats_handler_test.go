@@ -22,6 +22,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	oldsemconv "go.opentelemetry.io/otel/semconv/v1.37.0"         //nolint:depguard // Use of v1.37.0 is required for backward compatibility stability opt-in.
 	oldrpcconv "go.opentelemetry.io/otel/semconv/v1.37.0/rpcconv" //nolint:depguard // Use of v1.37.0 is required for backward compatibility stability opt-in.
 	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
 	"go.opentelemetry.io/otel/semconv/v1.43.0/rpcconv"
@@ -29,6 +30,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	testpb "google.golang.org/grpc/interop/grpc_testing"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
 
@@ -991,3 +993,271 @@ func TestMetricsSemconvOptIn(t *testing.T) {
 		})
 	}
 }
+
+func TestStatsHandlerMessageMetrics(t *testing.T) {
+	tests := []struct {
+		name           string
+		stabilityOptIn string
+		wantMetrics    bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:           "rpc_old",
+			stabilityOptIn: "rpc/old",
+			wantMetrics:    true,
+		},
+		{
+			name:           "rpc_dup",
+			stabilityOptIn: "rpc/dup",
+			wantMetrics:    true,
+		},
+	}
+
+	// Number of requests and responses sent by each call in doCalls.
+	wantPerRPC := map[string][2]int64{
+		"EmptyCall":           {1, 1},
+		"UnaryCall":           {1, 1},
+		"StreamingInputCall":  {4, 1},
+		"StreamingOutputCall": {1, 4},
+		"FullDuplexCall":      {4, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_SEMCONV_STABILITY_OPT_IN", tt.stabilityOptIn)
+
+			clientMetricReader := metric.NewManualReader()
+			clientMP := metric.NewMeterProvider(metric.WithReader(clientMetricReader))
+
+			serverMetricReader := metric.NewManualReader()
+			serverMP := metric.NewMeterProvider(metric.WithReader(serverMetricReader))
+
+			listener, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+			require.NoError(t, err, "failed to open port")
+
+			client := newGrpcTest(
+				t, listener,
+				[]grpc.DialOption{
+					grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+						otelgrpc.WithMeterProvider(clientMP),
+						otelgrpc.WithMessageMetrics(),
+					)),
+				},
+				[]grpc.ServerOption{
+					grpc.StatsHandler(otelgrpc.NewServerHandler(
+						otelgrpc.WithMeterProvider(serverMP),
+						otelgrpc.WithMessageMetrics(),
+					)),
+				},
+			)
+			doCalls(t.Context(), client)
+
+			for _, side := range []struct {
+				reader    metric.Reader
+				requests  string
+				responses string
+				reqSize   string
+				respSize  string
+			}{
+				{
+					reader:    clientMetricReader,
+					requests:  oldrpcconv.ClientRequestsPerRPC{}.Name(),
+					responses: oldrpcconv.ClientResponsesPerRPC{}.Name(),
+					reqSize:   oldrpcconv.ClientRequestSize{}.Name(),
+					respSize:  oldrpcconv.ClientResponseSize{}.Name(),
+				},
+				{
+					reader:    serverMetricReader,
+					requests:  oldrpcconv.ServerRequestsPerRPC{}.Name(),
+					responses: oldrpcconv.ServerResponsesPerRPC{}.Name(),
+					reqSize:   oldrpcconv.ServerRequestSize{}.Name(),
+					respSize:  oldrpcconv.ServerResponseSize{}.Name(),
+				},
+			} {
+				if !tt.wantMetrics {
+					// Wait for the call duration to be recorded.
+					var rm metricdata.ResourceMetrics
+					require.Eventually(t, func() bool {
+						rm = metricdata.ResourceMetrics{}
+						return side.reader.Collect(t.Context(), &rm) == nil && len(rm.ScopeMetrics) > 0
+					}, 3*time.Second, 10*time.Millisecond)
+					require.Len(t, rm.ScopeMetrics, 1)
+					for _, m := range rm.ScopeMetrics[0].Metrics {
+						assert.NotContains(t, []string{side.requests, side.responses, side.reqSize, side.respSize}, m.Name)
+					}
+					continue
+				}
+
+				var metrics map[string]metricdata.Metrics
+				require.Eventually(t, func() bool {
+					rm := metricdata.ResourceMetrics{}
+					if err := side.reader.Collect(t.Context(), &rm); err != nil {
+						return false
+					}
+					metrics = make(map[string]metricdata.Metrics)
+					for _, sm := range rm.ScopeMetrics {
+						if sm.Scope.SchemaURL != oldsemconv.SchemaURL {
+							continue
+						}
+						for _, m := range sm.Metrics {
+							metrics[m.Name] = m
+						}
+					}
+					h, ok := metrics[side.responses].Data.(metricdata.Histogram[int64])
+					return ok && len(h.DataPoints) == len(wantPerRPC)
+				}, 3*time.Second, 10*time.Millisecond)
+
+				assertOldAttrs := func(name string, attrs attribute.Set) {
+					assert.True(t, attrs.HasValue(oldsemconv.RPCSystemKey), name)
+					assert.True(t, attrs.HasValue(oldsemconv.RPCServiceKey), name)
+					assert.False(t, attrs.HasValue(semconv.RPCSystemNameKey), name)
+					assert.False(t, attrs.HasValue(semconv.RPCResponseStatusCodeKey), name)
+				}
+
+				for _, name := range []string{side.reqSize, side.respSize} {
+					h, ok := metrics[name].Data.(metricdata.Histogram[int64])
+					require.True(t, ok, "missing %s", name)
+					require.NotEmpty(t, h.DataPoints, name)
+					for _, dp := range h.DataPoints {
+						assertOldAttrs(name, dp.Attributes)
+					}
+				}
+
+				for i, name := range []string{side.requests, side.responses} {
+					h, ok := metrics[name].Data.(metricdata.Histogram[int64])
+					require.True(t, ok, "missing %s", name)
+					for _, dp := range h.DataPoints {
+						method, _ := dp.Attributes.Value(oldsemconv.RPCMethodKey)
+						want := wantPerRPC[method.AsString()]
+						assert.Equal(t, uint64(1), dp.Count, "%s %s", name, method.AsString())
+						assert.Equal(t, want[i], dp.Sum, "%s %s", name, method.AsString())
+						assertOldAttrs(name, dp.Attributes)
+						code, _ := dp.Attributes.Value(oldsemconv.RPCGRPCStatusCodeKey)
+						assert.Equal(t, int64(codes.OK), code.AsInt64(), name)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStatsHandlerMetadataAttributes(t *testing.T) {
+	tests := []struct {
+		name           string
+		stabilityOptIn string
+		reqKeys        []string
+		respKeys       []string
+	}{
+		{
+			name:     "default",
+			reqKeys:  []string{"rpc.request.metadata."},
+			respKeys: []string{"rpc.response.metadata."},
+		},
+		{
+			name:           "rpc_old",
+			stabilityOptIn: "rpc/old",
+			reqKeys:        []string{"rpc.grpc.request.metadata."},
+			respKeys:       []string{"rpc.grpc.response.metadata."},
+		},
+		{
+			name:           "rpc_dup",
+			stabilityOptIn: "rpc/dup",
+			reqKeys:        []string{"rpc.request.metadata.", "rpc.grpc.request.metadata."},
+			respKeys:       []string{"rpc.response.metadata.", "rpc.grpc.response.metadata."},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_SEMCONV_STABILITY_OPT_IN", tt.stabilityOptIn)
+
+			clientSR := tracetest.NewSpanRecorder()
+			clientTP := trace.NewTracerProvider(trace.WithSpanProcessor(clientSR))
+			serverSR := tracetest.NewSpanRecorder()
+			serverTP := trace.NewTracerProvider(trace.WithSpanProcessor(serverSR))
+			serverMetricReader := metric.NewManualReader()
+			serverMP := metric.NewMeterProvider(metric.WithReader(serverMetricReader))
+
+			opts := []otelgrpc.Option{
+				otelgrpc.WithRequestMetadata("Tenant", "authorization"),
+				otelgrpc.WithResponseMetadata("x-grpc-test-echo-initial"),
+				otelgrpc.WithRedactedMetadata("Authorization"),
+			}
+
+			listener, err := (&net.ListenConfig{}).Listen(t.Context(), "tcp", "127.0.0.1:0")
+			require.NoError(t, err, "failed to open port")
+			client := newGrpcTest(
+				t, listener,
+				[]grpc.DialOption{
+					grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+						append(opts, otelgrpc.WithTracerProvider(clientTP))...,
+					)),
+				},
+				[]grpc.ServerOption{
+					grpc.StatsHandler(otelgrpc.NewServerHandler(
+						append(opts,
+							otelgrpc.WithTracerProvider(serverTP),
+							otelgrpc.WithMeterProvider(serverMP),
+							otelgrpc.WithMetricRequestMetadata("tenant"),
+						)...,
+					)),
+				},
+			)
+
+			ctx := metadata.AppendToOutgoingContext(t.Context(),
+				"tenant", "acme",
+				"authorization", "Bearer secret",
+				"x-grpc-test-echo-initial", "hello",
+				"ignored", "value",
+			)
+			_, err = client.UnaryCall(ctx, &testpb.SimpleRequest{})
+			require.NoError(t, err)
+
+			var want []attribute.KeyValue
+			for _, prefix := range tt.reqKeys {
+				want = append(want,
+					attribute.StringSlice(prefix+"tenant", []string{"acme"}),
+					attribute.StringSlice(prefix+"authorization", []string{"REDACTED"}),
+				)
+			}
+			for _, prefix := range tt.respKeys {
+				want = append(want, attribute.StringSlice(prefix+"x-grpc-test-echo-initial", []string{"hello"}))
+			}
+
+			clientSpans := clientSR.Ended()
+			require.Len(t, clientSpans, 1)
+			var serverSpans []trace.ReadOnlySpan
+			require.Eventually(t, func() bool {
+				serverSpans = serverSR.Ended()
+				return len(serverSpans) == 1
+			}, time.Second, 10*time.Millisecond)
+
+			for _, span := range []trace.ReadOnlySpan{clientSpans[0], serverSpans[0]} {
+				for _, kv := range want {
+					assert.Contains(t, span.Attributes(), kv, span.SpanKind().String())
+				}
+				for _, kv := range span.Attributes() {
+					assert.NotContains(t, string(kv.Key), "ignored")
+				}
+			}
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, serverMetricReader.Collect(t.Context(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				h, ok := m.Data.(metricdata.Histogram[float64])
+				require.True(t, ok)
+				for _, dp := range h.DataPoints {
+					for _, prefix := range tt.reqKeys {
+						v, ok := dp.Attributes.Value(attribute.Key(prefix + "tenant"))
+						assert.True(t, ok, m.Name)
+						assert.Equal(t, []string{"acme"}, v.AsStringSlice())
+						assert.False(t, dp.Attributes.HasValue(attribute.Key(prefix+"authorization")))
+					}
+				}
+			}
+		})
+	}
+}
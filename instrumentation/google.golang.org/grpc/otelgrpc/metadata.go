@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelgrpc
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	oldsemconv "go.opentelemetry.io/otel/semconv/v1.37.0" //nolint:depguard // Use of v1.37.0 is required for backward compatibility stability opt-in.
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"google.golang.org/grpc/metadata"
+)
+
+// redactedValue replaces the values of redacted metadata keys.
+const redactedValue = "REDACTED"
+
+// normalizeMetadataKeys returns keys lowercased, the normalized form of gRPC
+// metadata keys.
+func normalizeMetadataKeys(keys []string) []string {
+	out := make([]string, 0, len(keys))
+	for _, k := range keys {
+		if k = strings.ToLower(strings.TrimSpace(k)); k != "" {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
+// requestMetadataAttrs returns the request metadata attributes for the keys
+// of md selected by keys.
+func (c *config) requestMetadataAttrs(md metadata.MD, keys []string) []attribute.KeyValue {
+	return c.metadataAttrs(md, keys, semconv.RPCRequestMetadata, oldsemconv.RPCGRPCRequestMetadata)
+}
+
+// responseMetadataAttrs returns the response metadata attributes for the keys
+// of md selected by keys.
+func (c *config) responseMetadataAttrs(md metadata.MD, keys []string) []attribute.KeyValue {
+	return c.metadataAttrs(md, keys, semconv.RPCResponseMetadata, oldsemconv.RPCGRPCResponseMetadata)
+}
+
+func (c *config) metadataAttrs(
+	md metadata.MD,
+	keys []string,
+	newAttr, oldAttr func(string, ...string) attribute.KeyValue,
+) []attribute.KeyValue {
+	if len(keys) == 0 || len(md) == 0 {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for _, k := range keys {
+		vals := md[k]
+		if len(vals) == 0 {
+			continue
+		}
+		if _, ok := c.RedactedMetadata[k]; ok {
+			redacted := make([]string, len(vals))
+			for i := range redacted {
+				redacted[i] = redactedValue
+			}
+			vals = redacted
+		}
+
+		if c.semconvMode == semconvModeOld || c.semconvMode == semconvModeDup {
+			attrs = append(attrs, oldAttr(k, vals...))
+		}
+		if c.semconvMode == semconvModeNew || c.semconvMode == semconvModeDup {
+			attrs = append(attrs, newAttr(k, vals...))
+		}
+	}
+	return attrs
+}
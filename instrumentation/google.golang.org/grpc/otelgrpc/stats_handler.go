@@ -5,19 +5,24 @@ package otelgrpc
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
+	oldsemconv "go.opentelemetry.io/otel/semconv/v1.37.0"         //nolint:depguard // Use of v1.37.0 is required for backward compatibility stability opt-in.
 	oldrpcconv "go.opentelemetry.io/otel/semconv/v1.37.0/rpcconv" //nolint:depguard // Use of v1.37.0 is required for backward compatibility stability opt-in.
 	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
 	"go.opentelemetry.io/otel/semconv/v1.43.0/rpcconv"
 	"go.opentelemetry.io/otel/trace"
 
 	grpc_codes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/stats"
 	"google.golang.org/grpc/status"
 
@@ -33,7 +38,72 @@ type dialTargetContextKey struct{}
 
 type gRPCContext struct {
 	metricAttrs []attribute.KeyValue
-	record      bool
+	// oldMetricAttrs are the attributes of the message metrics, following
+	// the v1.37 semantic conventions. They are only set when the message
+	// metrics are recorded.
+	oldMetricAttrs []attribute.KeyValue
+	record         bool
+
+	inMessages  atomic.Int64
+	outMessages atomic.Int64
+	inBytes     atomic.Int64
+	outBytes    atomic.Int64
+
+	// streaming is true for client, server and bidirectional streaming RPCs.
+	streaming atomic.Bool
+	// events is the number of message events sampled for the RPC.
+	events atomic.Int64
+	// lastProgress is the time of the last progress event, in Unix
+	// nanoseconds.
+	lastProgress atomic.Int64
+}
+
+// messageMetrics holds the instruments measuring the messages exchanged in an
+// RPC. The inbound instruments measure the messages received by the handler
+// (requests for a server, responses for a client) and the outbound
+// instruments the messages it sent.
+//
+// The instruments are only defined by the v1.37 semantic conventions, they
+// are recorded with the rpc/old and rpc/dup opt-ins.
+type messageMetrics struct {
+	inSize      metric.Int64Histogram
+	outSize     metric.Int64Histogram
+	inMessages  metric.Int64Histogram
+	outMessages metric.Int64Histogram
+}
+
+func newServerMessageMetrics(meter metric.Meter) *messageMetrics {
+	reqSize, err0 := oldrpcconv.NewServerRequestSize(meter)
+	respSize, err1 := oldrpcconv.NewServerResponseSize(meter)
+	reqs, err2 := oldrpcconv.NewServerRequestsPerRPC(meter)
+	resps, err3 := oldrpcconv.NewServerResponsesPerRPC(meter)
+	if err := errors.Join(err0, err1, err2, err3); err != nil {
+		otel.Handle(err)
+	}
+
+	return &messageMetrics{
+		inSize:      reqSize.Inst(),
+		outSize:     respSize.Inst(),
+		inMessages:  reqs.Inst(),
+		outMessages: resps.Inst(),
+	}
+}
+
+func newClientMessageMetrics(meter metric.Meter) *messageMetrics {
+	reqSize, err0 := oldrpcconv.NewClientRequestSize(meter)
+	respSize, err1 := oldrpcconv.NewClientResponseSize(meter)
+	reqs, err2 := oldrpcconv.NewClientRequestsPerRPC(meter)
+	resps, err3 := oldrpcconv.NewClientResponsesPerRPC(meter)
+	if err := errors.Join(err0, err1, err2, err3); err != nil {
+		otel.Handle(err)
+	}
+
+	return &messageMetrics{
+		inSize:      respSize.Inst(),
+		outSize:     reqSize.Inst(),
+		inMessages:  resps.Inst(),
+		outMessages: reqs.Inst(),
+	}
 }
 
 type serverHandler struct {
@@ -43,6 +113,7 @@ type serverHandler struct {
 
 	duration    rpcconv.ServerCallDuration
 	oldDuration oldrpcconv.ServerDuration
+	messages    *messageMetrics
 }
 
 // NewServerHandler creates a stats.Handler for a gRPC server.
@@ -88,6 +159,14 @@ func NewServerHandler(opts ...Option) stats.Handler {
 		}
 	}
 
+	if c.MessageMetrics && (c.semconvMode == semconvModeOld || c.semconvMode == semconvModeDup) {
+		h.messages = newServerMessageMetrics(c.MeterProvider.Meter(
+			ScopeName,
+			metric.WithInstrumentationVersion(Version),
+			metric.WithSchemaURL(oldsemconv.SchemaURL),
+		))
+	}
+
 	return h
 }
 
@@ -107,11 +186,14 @@ func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 	var name string
 	var attrs []attribute.KeyValue
 
+	var attrsOld []attribute.KeyValue
+
 	switch h.semconvMode {
 	case semconvModeOld:
 		name, attrs = internal.ParseFullMethodOld(info.FullMethodName)
+		attrsOld = attrs
 	case semconvModeDup:
-		var attrsNew, attrsOld []attribute.KeyValue
+		var attrsNew []attribute.KeyValue
 		name, attrsNew = internal.ParseFullMethod(info.FullMethodName)
 		_, attrsOld = internal.ParseFullMethodOld(info.FullMethodName)
 		// Combine both. We append New last so its rpc.method (fully qualified) wins when deduplicated.
@@ -127,10 +209,16 @@ func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 		record = h.Filter(info)
 	}
 
+	var md metadata.MD
+	if len(h.RequestMetadata) > 0 || len(h.MetricRequestMetadata) > 0 {
+		md, _ = metadata.FromIncomingContext(ctx)
+	}
+
 	if record {
 		// Make a new slice to avoid aliasing into the same attrs slice used by metrics.
 		spanAttributes := make([]attribute.KeyValue, 0, len(attrs)+len(h.SpanAttributes))
 		spanAttributes = append(append(spanAttributes, attrs...), h.SpanAttributes...)
+		spanAttributes = append(spanAttributes, h.requestMetadataAttrs(md, h.RequestMetadata)...)
 		opts := []trace.SpanStartOption{
 			trace.WithSpanKind(h.SpanKind),
 			trace.WithAttributes(spanAttributes...),
@@ -153,11 +241,17 @@ func (h *serverHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 		metricAttrs: append(attrs, h.MetricAttributes...),
 		record:      record,
 	}
+	gctx.metricAttrs = append(gctx.metricAttrs, h.requestMetadataAttrs(md, h.MetricRequestMetadata)...)
 
 	if h.MetricAttributesFn != nil {
 		extraAttrs := h.MetricAttributesFn(ctx)
 		gctx.metricAttrs = append(gctx.metricAttrs, extraAttrs...)
 	}
+	if h.messages != nil {
+		// Copy into a new slice, attrsOld may share the backing array of the
+		// metric attributes.
+		gctx.oldMetricAttrs = slices.Concat(attrsOld, gctx.metricAttrs[len(attrs):])
+	}
 
 	return context.WithValue(ctx, gRPCContextKey{}, &gctx)
 }
@@ -177,6 +271,7 @@ func (h *serverHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
 		rs,
 		dur,
 		oldDur,
+		h.messages,
 		serverStatus,
 	)
 }
@@ -188,6 +283,7 @@ type clientHandler struct {
 
 	duration    rpcconv.ClientCallDuration
 	oldDuration oldrpcconv.ClientDuration
+	messages    *messageMetrics
 }
 
 // NewClientHandler creates a stats.Handler for a gRPC client.
@@ -233,6 +329,14 @@ func NewClientHandler(opts ...Option) stats.Handler {
 		}
 	}
 
+	if c.MessageMetrics && (c.semconvMode == semconvModeOld || c.semconvMode == semconvModeDup) {
+		h.messages = newClientMessageMetrics(c.MeterProvider.Meter(
+			ScopeName,
+			metric.WithInstrumentationVersion(Version),
+			metric.WithSchemaURL(oldsemconv.SchemaURL),
+		))
+	}
+
 	return h
 }
 
@@ -241,11 +345,14 @@ func (h *clientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 	var name string
 	var attrs []attribute.KeyValue
 
+	var attrsOld []attribute.KeyValue
+
 	switch h.semconvMode {
 	case semconvModeOld:
 		name, attrs = internal.ParseFullMethodOld(info.FullMethodName)
+		attrsOld = attrs
 	case semconvModeDup:
-		var attrsNew, attrsOld []attribute.KeyValue
+		var attrsNew []attribute.KeyValue
 		name, attrsNew = internal.ParseFullMethod(info.FullMethodName)
 		_, attrsOld = internal.ParseFullMethodOld(info.FullMethodName)
 		// Combine both. We append New last so its rpc.method (fully qualified) wins when deduplicated.
@@ -261,10 +368,16 @@ func (h *clientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 		record = h.Filter(info)
 	}
 
+	var md metadata.MD
+	if len(h.RequestMetadata) > 0 || len(h.MetricRequestMetadata) > 0 {
+		md, _ = metadata.FromOutgoingContext(ctx)
+	}
+
 	if record {
 		// Make a new slice to avoid aliasing into the same attrs slice used by metrics.
 		spanAttributes := make([]attribute.KeyValue, 0, len(attrs)+len(h.SpanAttributes))
 		spanAttributes = append(append(spanAttributes, attrs...), h.SpanAttributes...)
+		spanAttributes = append(spanAttributes, h.requestMetadataAttrs(md, h.RequestMetadata)...)
 		ctx, _ = h.tracer.Start(
 			ctx,
 			name,
@@ -277,11 +390,17 @@ func (h *clientHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) cont
 		metricAttrs: append(attrs, h.MetricAttributes...),
 		record:      record,
 	}
+	gctx.metricAttrs = append(gctx.metricAttrs, h.requestMetadataAttrs(md, h.MetricRequestMetadata)...)
 
 	if h.MetricAttributesFn != nil {
 		extraAttrs := h.MetricAttributesFn(ctx)
 		gctx.metricAttrs = append(gctx.metricAttrs, extraAttrs...)
 	}
+	if h.messages != nil {
+		// Copy into a new slice, attrsOld may share the backing array of the
+		// metric attributes.
+		gctx.oldMetricAttrs = slices.Concat(attrsOld, gctx.metricAttrs[len(attrs):])
+	}
 
 	return inject(context.WithValue(ctx, gRPCContextKey{}, &gctx), h.Propagators)
 }
@@ -301,6 +420,7 @@ func (h *clientHandler) HandleRPC(ctx context.Context, rs stats.RPCStats) {
 		rs,
 		dur,
 		oldDur,
+		h.messages,
 		func(s *status.Status) (codes.Code, string) {
 			return codes.Error, s.Message()
 		},
@@ -317,11 +437,12 @@ func (*clientHandler) HandleConn(context.Context, stats.ConnStats) {
 	// no-op
 }
 
-func (*config) handleRPC(
+func (c *config) handleRPC(
 	ctx context.Context,
 	rs stats.RPCStats,
 	duration metric.Float64Histogram,
 	oldDuration metric.Float64Histogram,
+	messages *messageMetrics,
 	recordStatus func(*status.Status) (codes.Code, string),
 ) {
 	gctx, _ := ctx.Value(gRPCContextKey{}).(*gRPCContext)
@@ -333,6 +454,11 @@ func (*config) handleRPC(
 
 	switch rs := rs.(type) {
 	case *stats.Begin:
+		if gctx != nil {
+			gctx.streaming.Store(rs.IsClientStream || rs.IsServerStream)
+			gctx.lastProgress.Store(rs.BeginTime.UnixNano())
+		}
+
 		// Set server.address early from the dial target when available (upcoming
 		// interceptors will seed this). Covers both success and failure paths since Begin
 		// fires on every RPC regardless of outcome.
@@ -344,11 +470,30 @@ func (*config) handleRPC(
 				}
 				if gctx != nil {
 					gctx.metricAttrs = append(gctx.metricAttrs, attrs...)
+					if messages != nil {
+						gctx.oldMetricAttrs = append(gctx.oldMetricAttrs, attrs...)
+					}
 				}
 			}
 		}
 	case *stats.InPayload:
+		if gctx != nil {
+			id := gctx.inMessages.Add(1)
+			gctx.inBytes.Add(int64(rs.Length))
+			if messages != nil {
+				messages.inSize.Record(ctx, int64(rs.Length), metric.WithAttributeSet(attribute.NewSet(gctx.oldMetricAttrs...)))
+			}
+			if span.IsRecording() {
+				if c.ReceivedEvent {
+					c.messageEvent(span, gctx, oldsemconv.RPCMessageTypeReceived, id, rs.CompressedLength, rs.Length)
+				}
+				c.progressEvent(span, gctx, rs.RecvTime)
+			}
+		}
 	case *stats.InHeader:
+		if rs.Client && span.IsRecording() {
+			span.SetAttributes(c.responseMetadataAttrs(rs.Header, c.ResponseMetadata)...)
+		}
 		if !rs.Client && rs.LocalAddr != nil {
 			if span.IsRecording() {
 				span.SetAttributes(serverAddrAttrs(rs.LocalAddr.String())...)
@@ -356,8 +501,32 @@ func (*config) handleRPC(
 			// TODO: add server.address and server.port to metrics once the API supports opt-in attributes.
 		}
 	case *stats.OutPayload:
+		if gctx != nil {
+			id := gctx.outMessages.Add(1)
+			gctx.outBytes.Add(int64(rs.Length))
+			if messages != nil {
+				messages.outSize.Record(ctx, int64(rs.Length), metric.WithAttributeSet(attribute.NewSet(gctx.oldMetricAttrs...)))
+			}
+			if span.IsRecording() {
+				if c.SentEvent {
+					c.messageEvent(span, gctx, oldsemconv.RPCMessageTypeSent, id, rs.CompressedLength, rs.Length)
+				}
+				c.progressEvent(span, gctx, rs.SentTime)
+			}
+		}
+	case *stats.InTrailer:
+		if rs.Client && span.IsRecording() {
+			span.SetAttributes(c.responseMetadataAttrs(rs.Trailer, c.ResponseMetadata)...)
+		}
 	case *stats.OutTrailer:
+		if !rs.Client && span.IsRecording() {
+			span.SetAttributes(c.responseMetadataAttrs(rs.Trailer, c.ResponseMetadata)...)
+		}
 	case *stats.OutHeader:
+		if !rs.Client && span.IsRecording() {
+			span.SetAttributes(c.responseMetadataAttrs(rs.Header, c.ResponseMetadata)...)
+		}
+
 		// Only use the resolved IP from RemoteAddr when no dial target was seeded
 		// (i.e. NewClientHandler callers without interceptors). When dialTargetContextKey
 		// is present, Begin already set server.address to the hostname.
@@ -369,6 +538,9 @@ func (*config) handleRPC(
 				}
 				if gctx != nil {
 					gctx.metricAttrs = append(gctx.metricAttrs, attrs...)
+					if messages != nil {
+						gctx.oldMetricAttrs = append(gctx.oldMetricAttrs, attrs...)
+					}
 				}
 			}
 		}
@@ -388,6 +560,9 @@ func (*config) handleRPC(
 				span.SetStatus(c, m)
 			}
 			span.SetAttributes(rpcStatusAttr)
+			if c.StreamSummary && gctx != nil && gctx.streaming.Load() {
+				span.SetAttributes(streamAttrs(gctx)...)
+			}
 			span.End()
 		}
 
@@ -442,6 +617,20 @@ func (*config) handleRPC(
 			}
 		}
 
+		if gctx != nil && messages != nil {
+			code := grpc_codes.OK
+			if s != nil {
+				code = s.Code()
+			}
+			metricAttrs := make([]attribute.KeyValue, 0, len(gctx.oldMetricAttrs)+1)
+			metricAttrs = append(metricAttrs, gctx.oldMetricAttrs...)
+			metricAttrs = append(metricAttrs, oldsemconv.RPCGRPCStatusCodeKey.Int(int(code)))
+			set := metric.WithAttributeSet(attribute.NewSet(metricAttrs...))
+
+			messages.inMessages.Record(ctx, gctx.inMessages.Load(), set)
+			messages.outMessages.Record(ctx, gctx.outMessages.Load(), set)
+		}
+
 	default:
 		return
 	}
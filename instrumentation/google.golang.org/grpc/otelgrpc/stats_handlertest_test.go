@@ -5,6 +5,7 @@ package otelgrpc_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -333,3 +334,169 @@ func assertStatsHandlerServerMetrics(t *testing.T, reader metric.Reader, service
 	require.Len(t, rm.ScopeMetrics, 1)
 	metricdatatest.AssertEqual(t, want, rm.ScopeMetrics[0], metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
 }
+
+func TestStatsHandlerStreamMessageEvents(t *testing.T) {
+	const messages = 100
+
+	tests := []struct {
+		name         string
+		opts         []otelgrpc.Option
+		streaming    bool
+		wantEvents   int
+		wantProgress int
+		wantSummary  bool
+	}{
+		{
+			name:      "message events alone",
+			opts:      []otelgrpc.Option{otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents)},
+			streaming: true,
+		},
+		{
+			name: "all messages capped by default",
+			opts: []otelgrpc.Option{
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+				otelgrpc.WithMessageEventSampling(1),
+			},
+			streaming:  true,
+			wantEvents: 128,
+		},
+		{
+			name: "received only",
+			opts: []otelgrpc.Option{
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents),
+				otelgrpc.WithMessageEventSampling(1),
+			},
+			streaming:  true,
+			wantEvents: messages,
+		},
+		{
+			name: "sampled",
+			opts: []otelgrpc.Option{
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+				otelgrpc.WithMessageEventSampling(0.1),
+			},
+			streaming:  true,
+			wantEvents: 20,
+		},
+		{
+			name: "max events",
+			opts: []otelgrpc.Option{
+				otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+				otelgrpc.WithMessageEventSampling(1),
+				otelgrpc.WithMaxMessageEvents(5),
+			},
+			streaming:  true,
+			wantEvents: 5,
+		},
+		{
+			name:        "summary",
+			opts:        []otelgrpc.Option{otelgrpc.WithStreamSummary()},
+			streaming:   true,
+			wantSummary: true,
+		},
+		{
+			name: "summary unary",
+			opts: []otelgrpc.Option{otelgrpc.WithStreamSummary()},
+		},
+		{
+			name:      "progress",
+			opts:      []otelgrpc.Option{otelgrpc.WithStreamProgressEvents(10 * time.Second)},
+			streaming: true,
+			// One message is exchanged every second, in each direction.
+			wantProgress: messages / 10,
+		},
+		{
+			name: "progress unary",
+			opts: []otelgrpc.Option{otelgrpc.WithStreamProgressEvents(10 * time.Second)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+			h := otelgrpc.NewServerHandler(append(tt.opts, otelgrpc.WithTracerProvider(tp))...)
+
+			ctx := h.TagRPC(t.Context(), &stats.RPCTagInfo{FullMethodName: "/grpc.testing.TestService/FullDuplexCall"})
+
+			begin := time.Now()
+			h.HandleRPC(ctx, &stats.Begin{
+				BeginTime:      begin,
+				IsClientStream: tt.streaming,
+				IsServerStream: tt.streaming,
+			})
+			for i := range messages {
+				now := begin.Add(time.Duration(i+1) * time.Second)
+				h.HandleRPC(ctx, &stats.InPayload{Length: 10, CompressedLength: 5, RecvTime: now})
+				h.HandleRPC(ctx, &stats.OutPayload{Length: 20, CompressedLength: 8, SentTime: now})
+			}
+			h.HandleRPC(ctx, &stats.End{BeginTime: begin, EndTime: time.Now()})
+
+			spans := sr.Ended()
+			require.Len(t, spans, 1)
+			span := spans[0]
+
+			var gotEvents, gotProgress int
+			for _, e := range span.Events() {
+				switch e.Name {
+				case "message":
+					gotEvents++
+				case "progress":
+					gotProgress++
+				}
+			}
+			assert.Equal(t, tt.wantEvents, gotEvents, "message events")
+			assert.Equal(t, tt.wantProgress, gotProgress, "progress events")
+
+			summary := []attribute.KeyValue{
+				otelgrpc.ReceivedMessagesKey.Int64(messages),
+				otelgrpc.SentMessagesKey.Int64(messages),
+				otelgrpc.ReceivedBytesKey.Int64(10 * messages),
+				otelgrpc.SentBytesKey.Int64(20 * messages),
+			}
+			for _, kv := range summary {
+				if tt.wantSummary {
+					assert.Contains(t, span.Attributes(), kv)
+				} else {
+					assert.NotContains(t, span.Attributes(), kv)
+				}
+			}
+		})
+	}
+}
+
+func TestStatsHandlerMessageEventAttributes(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := trace.NewTracerProvider(trace.WithSpanProcessor(sr))
+	h := otelgrpc.NewClientHandler(
+		otelgrpc.WithTracerProvider(tp),
+		otelgrpc.WithMessageEvents(otelgrpc.ReceivedEvents, otelgrpc.SentEvents),
+		otelgrpc.WithMessageEventSampling(1),
+	)
+
+	ctx := h.TagRPC(t.Context(), &stats.RPCTagInfo{FullMethodName: "/grpc.testing.TestService/UnaryCall"})
+	h.HandleRPC(ctx, &stats.OutPayload{Client: true, Length: 20, CompressedLength: 8})
+	h.HandleRPC(ctx, &stats.InPayload{Client: true, Length: 10, CompressedLength: 5})
+	h.HandleRPC(ctx, &stats.End{Client: true})
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	events := spans[0].Events()
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "message", events[0].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("rpc.message.type", "SENT"),
+		attribute.Int64("rpc.message.id", 1),
+		attribute.Int64("rpc.message.compressed_size", 8),
+		attribute.Int64("rpc.message.uncompressed_size", 20),
+	}, events[0].Attributes)
+
+	assert.Equal(t, "message", events[1].Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("rpc.message.type", "RECEIVED"),
+		attribute.Int64("rpc.message.id", 1),
+		attribute.Int64("rpc.message.compressed_size", 5),
+		attribute.Int64("rpc.message.uncompressed_size", 10),
+	}, events[1].Attributes)
+}
@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelgrpc
+
+import (
+	"math"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	oldsemconv "go.opentelemetry.io/otel/semconv/v1.37.0" //nolint:depguard // Message event attributes were removed in later versions.
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attribute keys added to the span of a streaming RPC, see
+// [WithStreamSummary] and [WithStreamProgressEvents].
+const (
+	ReceivedMessagesKey = attribute.Key("rpc.grpc.received_messages") // the number of messages received
+	SentMessagesKey     = attribute.Key("rpc.grpc.sent_messages")     // the number of messages sent
+	ReceivedBytesKey    = attribute.Key("rpc.grpc.received_bytes")    // the uncompressed size of the messages received
+	SentBytesKey        = attribute.Key("rpc.grpc.sent_bytes")        // the uncompressed size of the messages sent
+)
+
+// streamAttrs returns the message and byte totals of the RPC tracked by gctx.
+func streamAttrs(gctx *gRPCContext) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ReceivedMessagesKey.Int64(gctx.inMessages.Load()),
+		SentMessagesKey.Int64(gctx.outMessages.Load()),
+		ReceivedBytesKey.Int64(gctx.inBytes.Load()),
+		SentBytesKey.Int64(gctx.outBytes.Load()),
+	}
+}
+
+// sampled returns whether the message with the 1-based id is recorded at the
+// sampling rate. The first message is always recorded, then every message
+// whose id crosses the next multiple of 1/rate.
+func sampled(id int64, rate float64) bool {
+	if rate >= 1 {
+		return true
+	}
+	return math.Ceil(float64(id)*rate) != math.Ceil(float64(id-1)*rate)
+}
+
+// messageEvent adds a message event to span if it is sampled and the
+// message event limit of the RPC is not reached.
+func (c *config) messageEvent(span trace.Span, gctx *gRPCContext, typ attribute.KeyValue, id int64, compressed, uncompressed int) {
+	if c.MessageEventSampling <= 0 || !sampled(id, c.MessageEventSampling) {
+		return
+	}
+	if gctx.events.Add(1) > int64(c.MaxMessageEvents) {
+		return
+	}
+
+	span.AddEvent("message",
+		trace.WithAttributes(
+			typ,
+			oldsemconv.RPCMessageIDKey.Int64(id),
+			oldsemconv.RPCMessageCompressedSizeKey.Int(compressed),
+			oldsemconv.RPCMessageUncompressedSizeKey.Int(uncompressed),
+		),
+	)
+}
+
+// progressEvent adds a progress event to the span of a streaming RPC if no
+// progress event was added in the last progress interval.
+func (c *config) progressEvent(span trace.Span, gctx *gRPCContext, now time.Time) {
+	if c.StreamProgressInterval <= 0 || !gctx.streaming.Load() {
+		return
+	}
+
+	last := gctx.lastProgress.Load()
+	if now.UnixNano()-last < int64(c.StreamProgressInterval) {
+		return
+	}
+	// Only one of concurrent senders and receivers records the event.
+	if !gctx.lastProgress.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	span.AddEvent("progress", trace.WithTimestamp(now), trace.WithAttributes(streamAttrs(gctx)...))
+}
@@ -15,11 +15,33 @@
 // ----------------------------------------------------------------------
 //
 //	process.cpu.time           state=user|system
+//	process.memory.usage
+//	process.memory.virtual
+//	process.unix.file_descriptor.count
+//	process.thread.count
+//	process.context_switches   process.context_switch.type=voluntary|involuntary
+//	process.disk.io            direction=read|write
 //	system.cpu.time            state=user|system|other|idle
 //	system.memory.usage        state=used|available
 //	system.memory.utilization  state=used|available
 //	system.network.io          direction=transmit|receive
 //
+// The process metrics not supported by the platform are not reported.
+//
+// The following metric events are produced when enabled with an option.
+//
+//	system.cpu.time              cpu.logical_number (WithPerCPUTime)
+//	system.cpu.load_average.1m   (WithLoadAverage)
+//	system.cpu.load_average.5m   (WithLoadAverage)
+//	system.cpu.load_average.15m  (WithLoadAverage)
+//	system.disk.io               system.device, direction=read|write
+//	system.disk.operations       system.device, direction=read|write
+//	system.filesystem.usage      system.device, mountpoint, type, mode, state=used|free|reserved
+//	system.network.io            network.interface.name (WithNetworkInterfaceMetrics)
+//	system.network.errors        network.interface.name, direction=transmit|receive
+//	system.network.packet.dropped network.interface.name, direction=transmit|receive
+//	process.*                    process.pid (WithChildProcesses)
+//
 // See https://github.com/open-telemetry/oteps/blob/main/text/0119-standard-system-metrics.md
 // for the definition of these metric instruments.
 package host
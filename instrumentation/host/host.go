@@ -25,6 +25,13 @@ import (
 // ScopeName is the instrumentation scope name.
 const ScopeName = "go.opentelemetry.io/contrib/instrumentation/host"
 
+// Readers of the host statistics, replaced in tests to simulate failures.
+var (
+	cpuTimes      = cpu.TimesWithContext
+	virtualMemory = mem.VirtualMemoryWithContext
+	netIOCounters = net.IOCountersWithContext
+)
+
 // Host reports the work-in-progress conventional host metrics specified by OpenTelemetry.
 type host struct {
 	config config
@@ -36,6 +43,21 @@ type config struct {
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
+
+	// PerCPU reports system.cpu.time for each logical CPU.
+	PerCPU bool
+	// LoadAverage reports the system.cpu.load_average.* metrics.
+	LoadAverage bool
+	// Disk reports the system.disk.* metrics.
+	Disk bool
+	// Filesystem reports the system.filesystem.usage metric.
+	Filesystem bool
+	// NetworkInterfaces reports the system.network.* metrics for each
+	// network interface.
+	NetworkInterfaces bool
+	// ChildPIDs are the process IDs of the child processes to report the
+	// process metrics of.
+	ChildPIDs []int
 }
 
 // Option supports configuring optional settings for host metrics.
@@ -58,6 +80,49 @@ func (o metricProviderOption) apply(c *config) {
 	}
 }
 
+type optionFunc func(*config)
+
+func (o optionFunc) apply(c *config) { o(c) }
+
+// WithPerCPUTime reports system.cpu.time for each logical CPU, with the
+// cpu.logical_number attribute, instead of the time of all the CPUs.
+func WithPerCPUTime() Option {
+	return optionFunc(func(c *config) { c.PerCPU = true })
+}
+
+// WithLoadAverage reports the system.cpu.load_average.1m,
+// system.cpu.load_average.5m and system.cpu.load_average.15m metrics.
+func WithLoadAverage() Option {
+	return optionFunc(func(c *config) { c.LoadAverage = true })
+}
+
+// WithDiskMetrics reports the system.disk.io and system.disk.operations
+// metrics of each disk device.
+func WithDiskMetrics() Option {
+	return optionFunc(func(c *config) { c.Disk = true })
+}
+
+// WithFilesystemMetrics reports the system.filesystem.usage metric of each
+// mounted filesystem.
+func WithFilesystemMetrics() Option {
+	return optionFunc(func(c *config) { c.Filesystem = true })
+}
+
+// WithNetworkInterfaceMetrics reports system.network.io for each network
+// interface, with the network.interface.name attribute, instead of the IO of
+// all the interfaces. The system.network.errors and
+// system.network.packet.dropped metrics of each interface are also reported.
+func WithNetworkInterfaceMetrics() Option {
+	return optionFunc(func(c *config) { c.NetworkInterfaces = true })
+}
+
+// WithChildProcesses reports the process metrics of the child processes with
+// the process IDs pids, with the process.pid attribute. Child processes that
+// are not running are skipped.
+func WithChildProcesses(pids ...int) Option {
+	return optionFunc(func(c *config) { c.ChildPIDs = append(c.ChildPIDs, pids...) })
+}
+
 // Attribute sets.
 var (
 	// Attribute sets for CPU time measurements.
@@ -194,106 +259,115 @@ func (h *host) register() error {
 	if netIO, err = systemconv.NewNetworkIO(h.meter); err != nil {
 		return err
 	}
+	instruments := []metric.Observable{
+		procCPUTime.Inst(),
+		cpuTime.Inst(),
+		memUse.Inst(),
+		memUtil.Inst(),
+		netIO.Inst(),
+	}
+
+	var sys systemInstruments
+	if err = sys.init(h.meter, h.config); err != nil {
+		return err
+	}
+	instruments = append(instruments, sys.instruments()...)
+
+	var procs processInstruments
+	if err = procs.init(context.Background(), h.meter, h.config, proc); err != nil {
+		return err
+	}
+	instruments = append(instruments, procs.instruments()...)
 
 	_, err = h.meter.RegisterCallback(
 		func(ctx context.Context, o metric.Observer) error {
 			lock.Lock()
 			defer lock.Unlock()
 
+			// A failing read only drops the metrics depending on it, the
+			// errors are reported together.
+			var errs []error
+
 			// This follows the OpenTelemetry Collector's "hostmetrics"
 			// receiver/hostmetricsreceiver/internal/scraper/processscraper
 			// measures User and System IOwait time.
 			// TODO: the Collector has per-OS compilation modules to support
 			// specific metrics that are not universal.
-			processTimes, err := proc.TimesWithContext(ctx)
-			if err != nil {
-				return err
+			if processTimes, err := proc.TimesWithContext(ctx); err != nil {
+				errs = append(errs, err)
+			} else {
+				o.ObserveFloat64(procCPUTime.Inst(), processTimes.User, procCPUTimeModeUser)
+				o.ObserveFloat64(procCPUTime.Inst(), processTimes.System, procCPUTimeModeSystem)
 			}
 
-			hostTimeSlice, err := cpu.TimesWithContext(ctx, false)
-			if err != nil {
-				return err
-			}
-			if len(hostTimeSlice) != 1 {
-				return errors.New("host CPU usage: incorrect summary count")
+			hostTimeSlice, err := cpuTimes(ctx, h.config.PerCPU)
+			switch {
+			case err != nil:
+				errs = append(errs, err)
+			case h.config.PerCPU:
+				for i, hostTime := range hostTimeSlice {
+					observeCPUTime(o, cpuTime, hostTime, cpuTime.AttrCPULogicalNumber(i))
+				}
+			case len(hostTimeSlice) != 1:
+				errs = append(errs, errors.New("host CPU usage: incorrect summary count"))
+			default:
+				hostTime := hostTimeSlice[0]
+				o.ObserveFloat64(cpuTime.Inst(), hostTime.User, cpuTimeModeUser)
+				o.ObserveFloat64(cpuTime.Inst(), hostTime.System, cpuTimeModeSystem)
+				o.ObserveFloat64(cpuTime.Inst(), otherCPUTime(hostTime), cpuTimeModeOther)
+				o.ObserveFloat64(cpuTime.Inst(), hostTime.Idle, cpuTimeModeIdle)
 			}
 
-			vmStats, err := mem.VirtualMemoryWithContext(ctx)
-			if err != nil {
-				return err
+			if vmStats, err := virtualMemory(ctx); err != nil {
+				errs = append(errs, err)
+			} else {
+				// Host memory usage
+				o.ObserveInt64(memUse.Inst(), clampInt64(vmStats.Used), memUseStateUsed)
+				o.ObserveInt64(memUse.Inst(), clampInt64(vmStats.Available), memUseStateFree)
+
+				// Host memory utilization
+				o.ObserveFloat64(
+					memUtil.Inst(),
+					float64(vmStats.Used)/float64(vmStats.Total), memUtilStateUsed,
+				)
+				o.ObserveFloat64(
+					memUtil.Inst(),
+					float64(vmStats.Available)/float64(vmStats.Total),
+					memUtilStateFree,
+				)
 			}
 
-			ioStats, err := net.IOCountersWithContext(ctx, false)
-			if err != nil {
-				return err
-			}
-			if len(ioStats) != 1 {
-				return errors.New("host network usage: incorrect summary count")
+			// Host network usage
+			ioStats, err := netIOCounters(ctx, h.config.NetworkInterfaces)
+			switch {
+			case err != nil:
+				errs = append(errs, err)
+			case h.config.NetworkInterfaces:
+				for _, stats := range ioStats {
+					sys.observeNetworkInterface(o, netIO, stats)
+				}
+			case len(ioStats) != 1:
+				errs = append(errs, errors.New("host network usage: incorrect summary count"))
+			default:
+				o.ObserveInt64(
+					netIO.Inst(),
+					clampInt64(ioStats[0].BytesSent),
+					netIOStateTransmit,
+				)
+				o.ObserveInt64(
+					netIO.Inst(),
+					clampInt64(ioStats[0].BytesRecv),
+					netIOStateReceive,
+				)
 			}
 
-			hostTime := hostTimeSlice[0]
-			o.ObserveFloat64(procCPUTime.Inst(), processTimes.User, procCPUTimeModeUser)
-			o.ObserveFloat64(procCPUTime.Inst(), processTimes.System, procCPUTimeModeSystem)
-
-			o.ObserveFloat64(cpuTime.Inst(), hostTime.User, cpuTimeModeUser)
-			o.ObserveFloat64(cpuTime.Inst(), hostTime.System, cpuTimeModeSystem)
-
-			// TODO(#244): "other" is a placeholder for actually dealing
-			// with these states.  Do users actually want this
-			// (unconditionally)?  How should we handle "iowait"
-			// if not all systems expose it?  Should we break
-			// these down by CPU?  If so, are users going to want
-			// to aggregate in-process?  See:
-			// https://github.com/open-telemetry/opentelemetry-go-contrib/issues/244
-			other := hostTime.Nice +
-				hostTime.Iowait +
-				hostTime.Irq +
-				hostTime.Softirq +
-				hostTime.Steal +
-				hostTime.Guest +
-				hostTime.GuestNice
-
-			o.ObserveFloat64(cpuTime.Inst(), other, cpuTimeModeOther)
-			o.ObserveFloat64(cpuTime.Inst(), hostTime.Idle, cpuTimeModeIdle)
-
-			// Host memory usage
-			o.ObserveInt64(memUse.Inst(), clampInt64(vmStats.Used), memUseStateUsed)
-			o.ObserveInt64(memUse.Inst(), clampInt64(vmStats.Available), memUseStateFree)
-
-			// Host memory utilization
-			o.ObserveFloat64(
-				memUtil.Inst(),
-				float64(vmStats.Used)/float64(vmStats.Total), memUtilStateUsed,
-			)
-			o.ObserveFloat64(
-				memUtil.Inst(),
-				float64(vmStats.Available)/float64(vmStats.Total),
-				memUtilStateFree,
-			)
-
-			// Host network usage
-			//
-			// TODO: These can be broken down by network
-			// interface, with similar questions to those posed
-			// about per-CPU measurements above.
-			o.ObserveInt64(
-				netIO.Inst(),
-				clampInt64(ioStats[0].BytesSent),
-				netIOStateTransmit,
-			)
-			o.ObserveInt64(
-				netIO.Inst(),
-				clampInt64(ioStats[0].BytesRecv),
-				netIOStateReceive,
-			)
-
-			return nil
+			return errors.Join(append(errs,
+				procs.observe(ctx, o, proc),
+				procs.observeChildren(ctx, o, procCPUTime),
+				sys.observe(ctx, o),
+			)...)
 		},
-		procCPUTime.Inst(),
-		cpuTime.Inst(),
-		memUse.Inst(),
-		memUtil.Inst(),
-		netIO.Inst(),
+		instruments...,
 	)
 	if err != nil {
 		return err
@@ -302,6 +376,37 @@ func (h *host) register() error {
 	return nil
 }
 
+// otherCPUTime returns the CPU time spent in the modes not reported
+// separately.
+func otherCPUTime(t cpu.TimesStat) float64 {
+	// TODO(#244): "other" is a placeholder for actually dealing
+	// with these states.  Do users actually want this
+	// (unconditionally)?  How should we handle "iowait"
+	// if not all systems expose it?  See:
+	// https://github.com/open-telemetry/opentelemetry-go-contrib/issues/244
+	return t.Nice +
+		t.Iowait +
+		t.Irq +
+		t.Softirq +
+		t.Steal +
+		t.Guest +
+		t.GuestNice
+}
+
+func observeCPUTime(o metric.Observer, inst systemconv.CPUTime, t cpu.TimesStat, attr attribute.KeyValue) {
+	for _, m := range []struct {
+		mode systemconv.CPUModeAttr
+		val  float64
+	}{
+		{systemconv.CPUModeUser, t.User},
+		{systemconv.CPUModeSystem, t.System},
+		{systemconv.CPUModeAttr("other"), otherCPUTime(t)},
+		{systemconv.CPUModeIdle, t.Idle},
+	} {
+		o.ObserveFloat64(inst.Inst(), m.val, metric.WithAttributes(inst.AttrCPUMode(m.mode), attr))
+	}
+}
+
 func clampInt64(v uint64) int64 {
 	if v > math.MaxInt64 {
 		return math.MaxInt64
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/shirou/gopsutil/v4/cpu"
+	"github.com/shirou/gopsutil/v4/mem"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/systemconv"
+)
+
+func TestHostMetricsReadError(t *testing.T) {
+	errCPU := errors.New("cpu times")
+	errMem := errors.New("virtual memory")
+	origCPUTimes, origVirtualMemory := cpuTimes, virtualMemory
+	t.Cleanup(func() { cpuTimes, virtualMemory = origCPUTimes, origVirtualMemory })
+	cpuTimes = func(context.Context, bool) ([]cpu.TimesStat, error) { return nil, errCPU }
+	virtualMemory = func(context.Context) (*mem.VirtualMemoryStat, error) { return nil, errMem }
+
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	require.NoError(t, Start(WithMeterProvider(mp), WithLoadAverage()))
+
+	var rm metricdata.ResourceMetrics
+	err := reader.Collect(t.Context(), &rm)
+	assert.ErrorIs(t, err, errCPU)
+	assert.ErrorIs(t, err, errMem)
+
+	require.Len(t, rm.ScopeMetrics, 1)
+	observed := make(map[string]bool)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		observed[m.Name] = true
+	}
+	// The metrics of the failing reads are dropped, the other ones are
+	// still observed.
+	assert.False(t, observed[systemconv.CPUTime{}.Name()])
+	assert.False(t, observed[systemconv.MemoryUsage{}.Name()])
+	assert.True(t, observed["process.cpu.time"])
+	assert.True(t, observed[systemconv.NetworkIO{}.Name()])
+	assert.True(t, observed["system.cpu.load_average.1m"])
+}
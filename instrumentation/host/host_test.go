@@ -4,8 +4,12 @@
 package host_test
 
 import (
+	"math"
+	"os/exec"
+	"runtime"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/sdk/instrumentation"
@@ -125,5 +129,130 @@ func TestHostMetrics(t *testing.T) {
 			},
 		},
 	}
-	metricdatatest.AssertEqual(t, want, rm.ScopeMetrics[0], metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+	// The process metrics supported depend on the platform, see
+	// TestProcessMetrics.
+	got := rm.ScopeMetrics[0]
+	require.GreaterOrEqual(t, len(got.Metrics), len(want.Metrics))
+	got.Metrics = got.Metrics[:len(want.Metrics)]
+	metricdatatest.AssertEqual(t, want, got, metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
+}
+
+func TestHostMetricsOptions(t *testing.T) {
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	err := host.Start(
+		host.WithMeterProvider(mp),
+		host.WithPerCPUTime(),
+		host.WithLoadAverage(),
+		host.WithDiskMetrics(),
+		host.WithFilesystemMetrics(),
+		host.WithNetworkInterfaceMetrics(),
+	)
+	require.NoError(t, err)
+	rm := metricdata.ResourceMetrics{}
+	err = reader.Collect(t.Context(), &rm)
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := make(map[string]metricdata.Metrics)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m
+	}
+	for _, name := range []string{
+		"system.cpu.load_average.1m",
+		"system.cpu.load_average.5m",
+		"system.cpu.load_average.15m",
+	} {
+		require.Contains(t, metrics, name)
+		assert.Equal(t, "{thread}", metrics[name].Unit)
+	}
+
+	require.Contains(t, metrics, systemconv.CPUTime{}.Name())
+	for _, dp := range metrics[systemconv.CPUTime{}.Name()].Data.(metricdata.Sum[float64]).DataPoints {
+		_, ok := dp.Attributes.Value("cpu.logical_number")
+		assert.True(t, ok, "missing cpu.logical_number attribute")
+	}
+
+	require.Contains(t, metrics, systemconv.NetworkIO{}.Name())
+	for _, name := range []string{
+		systemconv.NetworkIO{}.Name(),
+		systemconv.NetworkErrors{}.Name(),
+		systemconv.NetworkPacketDropped{}.Name(),
+	} {
+		for _, dp := range metrics[name].Data.(metricdata.Sum[int64]).DataPoints {
+			_, ok := dp.Attributes.Value("network.interface.name")
+			assert.True(t, ok, "missing network.interface.name attribute in %s", name)
+		}
+	}
+
+	if m, ok := metrics[systemconv.FilesystemUsage{}.Name()]; ok {
+		for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+			assert.True(t, dp.Attributes.HasValue("system.filesystem.state"))
+			assert.True(t, dp.Attributes.HasValue("system.filesystem.mountpoint"))
+		}
+	}
+}
+
+func TestProcessMetrics(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process metrics test requires Linux")
+	}
+	sleep, err := exec.LookPath("sleep")
+	if err != nil {
+		t.Skip("sleep command not found")
+	}
+	child := exec.Command(sleep, "60")
+	require.NoError(t, child.Start())
+	t.Cleanup(func() {
+		_ = child.Process.Kill()
+		_ = child.Wait()
+	})
+
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	// The second child process does not exist and is skipped.
+	err = host.Start(host.WithMeterProvider(mp), host.WithChildProcesses(child.Process.Pid, math.MaxInt32))
+	require.NoError(t, err)
+	rm := metricdata.ResourceMetrics{}
+	err = reader.Collect(t.Context(), &rm)
+	require.NoError(t, err)
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	pids := make(map[string][]int64)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		var sets []attribute.Set
+		switch data := m.Data.(type) {
+		case metricdata.Sum[int64]:
+			for _, dp := range data.DataPoints {
+				sets = append(sets, dp.Attributes)
+			}
+		case metricdata.Sum[float64]:
+			for _, dp := range data.DataPoints {
+				sets = append(sets, dp.Attributes)
+			}
+		}
+		for _, set := range sets {
+			pid := int64(-1)
+			if v, ok := set.Value("process.pid"); ok {
+				pid = v.AsInt64()
+			}
+			pids[m.Name] = append(pids[m.Name], pid)
+		}
+	}
+
+	childPID := int64(child.Process.Pid)
+	for _, name := range []string{
+		processconv.CPUTime{}.Name(),
+		processconv.MemoryUsageObservable{}.Name(),
+		processconv.MemoryVirtualObservable{}.Name(),
+		processconv.UnixFileDescriptorCountObservable{}.Name(),
+		processconv.ThreadCountObservable{}.Name(),
+		processconv.ContextSwitchesObservable{}.Name(),
+		processconv.DiskIOObservable{}.Name(),
+	} {
+		require.Contains(t, pids, name)
+		assert.Contains(t, pids[name], int64(-1), "%s of the current process", name)
+		assert.Contains(t, pids[name], childPID, "%s of the child process", name)
+		assert.NotContains(t, pids[name], int64(math.MaxInt32), name)
+	}
 }
@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime"
+
+	"github.com/shirou/gopsutil/v4/process"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/processconv"
+)
+
+// processInstruments holds the instruments of the process metrics other than
+// process.cpu.time.
+//
+// Not every platform supports all the metrics. A metric is only reported if
+// it can be read for the current process when the instruments are created.
+type processInstruments struct {
+	memUsage    processconv.MemoryUsageObservable
+	memVirtual  processconv.MemoryVirtualObservable
+	fds         processconv.UnixFileDescriptorCountObservable
+	threads     processconv.ThreadCountObservable
+	ctxSwitches processconv.ContextSwitchesObservable
+	diskIO      processconv.DiskIOObservable
+
+	memory, fd, thread, ctxSwitch, disk bool
+
+	children []int32
+}
+
+func (p *processInstruments) init(ctx context.Context, meter metric.Meter, cfg config, proc *process.Process) error {
+	for _, pid := range cfg.ChildPIDs {
+		if pid > math.MaxInt32 || pid < 0 {
+			return fmt.Errorf("invalid child process ID: %d", pid)
+		}
+		p.children = append(p.children, int32(pid))
+	}
+
+	var err error
+	if _, e := proc.MemoryInfoWithContext(ctx); e == nil {
+		p.memory = true
+		if p.memUsage, err = processconv.NewMemoryUsageObservable(meter); err != nil {
+			return err
+		}
+		if p.memVirtual, err = processconv.NewMemoryVirtualObservable(meter); err != nil {
+			return err
+		}
+	}
+	if _, e := proc.NumFDsWithContext(ctx); e == nil {
+		p.fd = true
+		if p.fds, err = processconv.NewUnixFileDescriptorCountObservable(meter); err != nil {
+			return err
+		}
+	}
+	if _, e := proc.NumThreadsWithContext(ctx); e == nil {
+		p.thread = true
+		if p.threads, err = processconv.NewThreadCountObservable(meter); err != nil {
+			return err
+		}
+	}
+	if _, e := proc.NumCtxSwitchesWithContext(ctx); e == nil {
+		p.ctxSwitch = true
+		if p.ctxSwitches, err = processconv.NewContextSwitchesObservable(meter); err != nil {
+			return err
+		}
+	}
+	if _, e := proc.IOCountersWithContext(ctx); e == nil {
+		p.disk = true
+		if p.diskIO, err = processconv.NewDiskIOObservable(meter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *processInstruments) instruments() []metric.Observable {
+	var obs []metric.Observable
+	if p.memory {
+		obs = append(obs, p.memUsage.Inst(), p.memVirtual.Inst())
+	}
+	if p.fd {
+		obs = append(obs, p.fds.Inst())
+	}
+	if p.thread {
+		obs = append(obs, p.threads.Inst())
+	}
+	if p.ctxSwitch {
+		obs = append(obs, p.ctxSwitches.Inst())
+	}
+	if p.disk {
+		obs = append(obs, p.diskIO.Inst())
+	}
+	return obs
+}
+
+// observe observes the process metrics of proc with attrs.
+func (p *processInstruments) observe(ctx context.Context, o metric.Observer, proc *process.Process, attrs ...attribute.KeyValue) error {
+	var errs []error
+	if p.memory {
+		mem, err := proc.MemoryInfoWithContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			o.ObserveInt64(p.memUsage.Inst(), clampInt64(mem.RSS), metric.WithAttributes(attrs...))
+			o.ObserveInt64(p.memVirtual.Inst(), clampInt64(mem.VMS), metric.WithAttributes(attrs...))
+		}
+	}
+	if p.fd {
+		n, err := proc.NumFDsWithContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			o.ObserveInt64(p.fds.Inst(), int64(n), metric.WithAttributes(attrs...))
+		}
+	}
+	if p.thread {
+		n, err := proc.NumThreadsWithContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			o.ObserveInt64(p.threads.Inst(), int64(n), metric.WithAttributes(attrs...))
+		}
+	}
+	if p.ctxSwitch {
+		switches, err := proc.NumCtxSwitchesWithContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			o.ObserveInt64(p.ctxSwitches.Inst(), switches.Voluntary, metric.WithAttributes(
+				append(attrs[:len(attrs):len(attrs)], p.ctxSwitches.AttrContextSwitchType(processconv.ContextSwitchTypeVoluntary))...,
+			))
+			o.ObserveInt64(p.ctxSwitches.Inst(), switches.Involuntary, metric.WithAttributes(
+				append(attrs[:len(attrs):len(attrs)], p.ctxSwitches.AttrContextSwitchType(processconv.ContextSwitchTypeInvoluntary))...,
+			))
+		}
+	}
+	if p.disk {
+		io, err := proc.IOCountersWithContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			// Windows only reports the IO of the process, that includes its
+			// disk IO, the other platforms report the disk IO separately.
+			read, write := io.DiskReadBytes, io.DiskWriteBytes
+			if runtime.GOOS == "windows" {
+				read, write = io.ReadBytes, io.WriteBytes
+			}
+			o.ObserveInt64(p.diskIO.Inst(), clampInt64(read), metric.WithAttributes(
+				append(attrs[:len(attrs):len(attrs)], p.diskIO.AttrDiskIODirection(processconv.DiskIODirectionRead))...,
+			))
+			o.ObserveInt64(p.diskIO.Inst(), clampInt64(write), metric.WithAttributes(
+				append(attrs[:len(attrs):len(attrs)], p.diskIO.AttrDiskIODirection(processconv.DiskIODirectionWrite))...,
+			))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// observeChildren observes the CPU time and process metrics of the child
+// processes, with the process.pid attribute. Child processes that are not
+// running are skipped.
+func (p *processInstruments) observeChildren(ctx context.Context, o metric.Observer, cpuTime processconv.CPUTime) error {
+	var errs []error
+	for _, pid := range p.children {
+		proc, err := process.NewProcessWithContext(ctx, pid)
+		if err != nil {
+			if !errors.Is(err, process.ErrorProcessNotRunning) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		pidAttr := semconv.ProcessPID(int(pid))
+
+		times, err := proc.TimesWithContext(ctx)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			o.ObserveFloat64(cpuTime.Inst(), times.User, metric.WithAttributes(
+				pidAttr, cpuTime.AttrCPUMode(processconv.CPUModeUser),
+			))
+			o.ObserveFloat64(cpuTime.Inst(), times.System, metric.WithAttributes(
+				pidAttr, cpuTime.AttrCPUMode(processconv.CPUModeSystem),
+			))
+		}
+
+		if err := p.observe(ctx, o, proc, pidAttr); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package host
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/shirou/gopsutil/v4/disk"
+	"github.com/shirou/gopsutil/v4/load"
+	"github.com/shirou/gopsutil/v4/net"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/systemconv"
+)
+
+// systemInstruments holds the instruments of the optional system metrics.
+// Instruments of the metrics not enabled are not created.
+type systemInstruments struct {
+	cfg config
+
+	load1, load5, load15 metric.Float64ObservableGauge
+
+	diskIO  systemconv.DiskIOObservable
+	diskOps systemconv.DiskOperationsObservable
+
+	fsUsage systemconv.FilesystemUsageObservable
+
+	netErrors  systemconv.NetworkErrorsObservable
+	netDropped systemconv.NetworkPacketDroppedObservable
+}
+
+func (s *systemInstruments) init(meter metric.Meter, cfg config) error {
+	s.cfg = cfg
+
+	var err error
+	if cfg.LoadAverage {
+		for _, l := range []struct {
+			inst   *metric.Float64ObservableGauge
+			name   string
+			period string
+		}{
+			{&s.load1, "system.cpu.load_average.1m", "1 minute"},
+			{&s.load5, "system.cpu.load_average.5m", "5 minutes"},
+			{&s.load15, "system.cpu.load_average.15m", "15 minutes"},
+		} {
+			*l.inst, err = meter.Float64ObservableGauge(
+				l.name,
+				metric.WithDescription("Average CPU load over "+l.period+"."),
+				metric.WithUnit("{thread}"),
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if cfg.Disk {
+		if s.diskIO, err = systemconv.NewDiskIOObservable(meter); err != nil {
+			return err
+		}
+		if s.diskOps, err = systemconv.NewDiskOperationsObservable(meter); err != nil {
+			return err
+		}
+	}
+	if cfg.Filesystem {
+		if s.fsUsage, err = systemconv.NewFilesystemUsageObservable(meter); err != nil {
+			return err
+		}
+	}
+	if cfg.NetworkInterfaces {
+		if s.netErrors, err = systemconv.NewNetworkErrorsObservable(meter); err != nil {
+			return err
+		}
+		if s.netDropped, err = systemconv.NewNetworkPacketDroppedObservable(meter); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *systemInstruments) instruments() []metric.Observable {
+	var obs []metric.Observable
+	if s.cfg.LoadAverage {
+		obs = append(obs, s.load1, s.load5, s.load15)
+	}
+	if s.cfg.Disk {
+		obs = append(obs, s.diskIO.Inst(), s.diskOps.Inst())
+	}
+	if s.cfg.Filesystem {
+		obs = append(obs, s.fsUsage.Inst())
+	}
+	if s.cfg.NetworkInterfaces {
+		obs = append(obs, s.netErrors.Inst(), s.netDropped.Inst())
+	}
+	return obs
+}
+
+// observe observes the enabled load average, disk and filesystem metrics.
+// The metrics of a group are observed even if another group fails.
+func (s *systemInstruments) observe(ctx context.Context, o metric.Observer) error {
+	var errs []error
+	if s.cfg.LoadAverage {
+		errs = append(errs, s.observeLoadAverage(ctx, o))
+	}
+	if s.cfg.Disk {
+		errs = append(errs, s.observeDisk(ctx, o))
+	}
+	if s.cfg.Filesystem {
+		errs = append(errs, s.observeFilesystem(ctx, o))
+	}
+	return errors.Join(errs...)
+}
+
+// observeLoadAverage observes the 1, 5 and 15 minutes load averages.
+func (s *systemInstruments) observeLoadAverage(ctx context.Context, o metric.Observer) error {
+	avg, err := load.AvgWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	o.ObserveFloat64(s.load1, avg.Load1)
+	o.ObserveFloat64(s.load5, avg.Load5)
+	o.ObserveFloat64(s.load15, avg.Load15)
+	return nil
+}
+
+// observeDisk observes the IO and operations of the disks.
+func (s *systemInstruments) observeDisk(ctx context.Context, o metric.Observer) error {
+	counters, err := disk.IOCountersWithContext(ctx)
+	if err != nil {
+		return err
+	}
+	for name, c := range counters {
+		device := s.diskIO.AttrDevice(name)
+		read := metric.WithAttributes(device, s.diskIO.AttrDiskIODirection(systemconv.DiskIODirectionRead))
+		write := metric.WithAttributes(device, s.diskIO.AttrDiskIODirection(systemconv.DiskIODirectionWrite))
+		o.ObserveInt64(s.diskIO.Inst(), clampInt64(c.ReadBytes), read)
+		o.ObserveInt64(s.diskIO.Inst(), clampInt64(c.WriteBytes), write)
+		o.ObserveInt64(s.diskOps.Inst(), clampInt64(c.ReadCount), read)
+		o.ObserveInt64(s.diskOps.Inst(), clampInt64(c.WriteCount), write)
+	}
+	return nil
+}
+
+// observeFilesystem observes the usage of the mounted filesystems.
+func (s *systemInstruments) observeFilesystem(ctx context.Context, o metric.Observer) error {
+	partitions, err := disk.PartitionsWithContext(ctx, false)
+	if err != nil {
+		return err
+	}
+	for _, p := range partitions {
+		usage, err := disk.UsageWithContext(ctx, p.Mountpoint)
+		if err != nil {
+			// The filesystem may not be accessible to this process,
+			// report the other ones.
+			continue
+		}
+		mode := "rw"
+		if slices.Contains(p.Opts, "ro") {
+			mode = "ro"
+		}
+		attrs := []attribute.KeyValue{
+			s.fsUsage.AttrDevice(p.Device),
+			s.fsUsage.AttrFilesystemMountpoint(p.Mountpoint),
+			s.fsUsage.AttrFilesystemType(systemconv.FilesystemTypeAttr(p.Fstype)),
+			s.fsUsage.AttrFilesystemMode(mode),
+		}
+		// The space reserved for the root user is neither used nor
+		// free for other users.
+		var reserved uint64
+		if usage.Total > usage.Used+usage.Free {
+			reserved = usage.Total - usage.Used - usage.Free
+		}
+		for _, st := range []struct {
+			state systemconv.FilesystemStateAttr
+			val   uint64
+		}{
+			{systemconv.FilesystemStateUsed, usage.Used},
+			{systemconv.FilesystemStateFree, usage.Free},
+			{systemconv.FilesystemStateReserved, reserved},
+		} {
+			o.ObserveInt64(s.fsUsage.Inst(), clampInt64(st.val), metric.WithAttributes(
+				append(attrs[:len(attrs):len(attrs)], s.fsUsage.AttrFilesystemState(st.state))...,
+			))
+		}
+	}
+	return nil
+}
+
+// observeNetworkInterface observes the IO, errors and dropped packets of a
+// network interface.
+func (s *systemInstruments) observeNetworkInterface(o metric.Observer, netIO systemconv.NetworkIO, stats net.IOCountersStat) {
+	name := netIO.AttrNetworkInterfaceName(stats.Name)
+	transmit := metric.WithAttributes(name, netIO.AttrNetworkIODirection(systemconv.NetworkIODirectionTransmit))
+	receive := metric.WithAttributes(name, netIO.AttrNetworkIODirection(systemconv.NetworkIODirectionReceive))
+
+	o.ObserveInt64(netIO.Inst(), clampInt64(stats.BytesSent), transmit)
+	o.ObserveInt64(netIO.Inst(), clampInt64(stats.BytesRecv), receive)
+	o.ObserveInt64(s.netErrors.Inst(), clampInt64(stats.Errout), transmit)
+	o.ObserveInt64(s.netErrors.Inst(), clampInt64(stats.Errin), receive)
+	o.ObserveInt64(s.netDropped.Inst(), clampInt64(stats.Dropout), transmit)
+	o.ObserveInt64(s.netDropped.Inst(), clampInt64(stats.Dropin), receive)
+}
@@ -222,6 +222,42 @@ func TestNewServerRecordMetrics(t *testing.T) {
 	}
 }
 
+func TestNewRecordMetricsStreaming(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	mp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+	server := semconv.NewHTTPServer(mp.Meter("test"))
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://example.com", http.NoBody)
+	require.NoError(t, err)
+
+	server.RecordMetrics(t.Context(), semconv.ServerMetricData{
+		ServerName:   "stuff",
+		ResponseSize: 200,
+		Streaming:    true,
+		MetricAttributes: semconv.MetricAttributes{
+			Req:        req,
+			StatusCode: http.StatusOK,
+		},
+		MetricData: semconv.MetricData{
+			RequestSize:     100,
+			RequestDuration: time.Hour,
+		},
+	})
+
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	var names []string
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		names = append(names, m.Name)
+	}
+	assert.ElementsMatch(t, []string{
+		"http.server.request.body.size",
+		"http.server.response.body.size",
+	}, names)
+}
+
 func TestNewTraceResponse(t *testing.T) {
 	testCases := []struct {
 		name string
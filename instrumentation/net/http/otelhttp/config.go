@@ -29,9 +29,12 @@ type config struct {
 	PublicEndpointFn  func(*http.Request) bool
 	ReadEvent         bool
 	WriteEvent        bool
+	FlushEvent        bool
+	DetectStreaming   bool
 	Filters           []Filter
 	SpanNameFormatter func(string, *http.Request) string
 	ClientTrace       func(context.Context) *httptrace.ClientTrace
+	ResendCount       bool
 
 	TracerProvider     trace.TracerProvider
 	MeterProvider      metric.MeterProvider
@@ -140,6 +143,7 @@ const (
 	unspecifiedEvents Event = iota
 	ReadEvents
 	WriteEvents
+	FlushEvents
 )
 
 // WithMessageEvents configures the Handler to record the specified events
@@ -151,6 +155,7 @@ const (
 //     using the ReadBytesKey
 //   - WriteEvents: Record the number of bytes written after every http.ResponeWriter.Write
 //     using the WriteBytesKey
+//   - FlushEvents: Record a "flush" event after every http.Flusher.Flush
 func WithMessageEvents(events ...Event) Option {
 	return optionFunc(func(c *config) {
 		for _, e := range events {
@@ -159,11 +164,33 @@ func WithMessageEvents(events ...Event) Option {
 				c.ReadEvent = true
 			case WriteEvents:
 				c.WriteEvent = true
+			case FlushEvents:
+				c.FlushEvent = true
 			}
 		}
 	})
 }
 
+// WithStreamingDetection configures the Handler to detect long-lived
+// streaming requests. A request is considered streaming when its connection
+// is hijacked using [http.Hijacker], when a 101 Switching Protocols response
+// is written (e.g. a WebSocket upgrade), or when a response with a
+// "text/event-stream" Content-Type is written (Server-Sent Events).
+//
+// The span of a streaming request is ended as soon as the stream is detected,
+// and the request is not recorded in the request duration histogram. The
+// status code of a response written directly on a hijacked connection is
+// unknown: it is recorded as 101 Switching Protocols if the request has a
+// "Connection: Upgrade" header, and left unset otherwise. If message events
+// are enabled with [WithMessageEvents], the events that occur after the
+// stream is detected are recorded on a child span named after the request
+// span with a " stream" suffix. That span ends when the handler returns.
+func WithStreamingDetection() Option {
+	return optionFunc(func(c *config) {
+		c.DetectStreaming = true
+	})
+}
+
 // WithSpanNameFormatter takes a function that will be called on every
 // request and the returned string will become the Span Name.
 //
@@ -184,6 +211,17 @@ func WithClientTrace(f func(context.Context) *httptrace.ClientTrace) Option {
 	})
 }
 
+// WithResendCount configures the Transport to record the
+// http.request.resend_count attribute on spans of requests that are re-sent.
+// The resend count is the number of redirects http.Client followed to produce
+// the request, plus the resend count propagated in the request context with
+// [ContextWithResendCount] (e.g. by a [RetryTransport]).
+func WithResendCount() Option {
+	return optionFunc(func(c *config) {
+		c.ResendCount = true
+	})
+}
+
 // WithServerName returns an Option that sets the name of the (virtual) server
 // handling requests.
 func WithServerName(server string) Option {
@@ -5,6 +5,8 @@ package otelhttp
 
 import (
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/felixge/httpsnoop"
@@ -27,6 +29,8 @@ type middleware struct {
 	spanStartOptions   []trace.SpanStartOption
 	readEvent          bool
 	writeEvent         bool
+	flushEvent         bool
+	detectStreaming    bool
 	filters            []Filter
 	spanNameFormatter  func(string, *http.Request) string
 	publicEndpointFn   func(*http.Request) bool
@@ -75,6 +79,8 @@ func (h *middleware) configure(c *config) {
 	h.spanStartOptions = c.SpanStartOptions
 	h.readEvent = c.ReadEvent
 	h.writeEvent = c.WriteEvent
+	h.flushEvent = c.FlushEvent
+	h.detectStreaming = c.DetectStreaming
 	h.filters = c.Filters
 	h.spanNameFormatter = c.SpanNameFormatter
 	h.publicEndpointFn = c.PublicEndpointFn
@@ -127,10 +133,15 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	ctx, span := tracer.Start(ctx, h.spanNameFormatter(h.operation, r), opts...)
 	defer span.End()
 
+	// Message events are recorded on the request span, or on the stream span
+	// once a streaming request ended the request span.
+	events := &eventSpan{span: span}
+	defer events.end()
+
 	readRecordFunc := func(int64) {}
 	if h.readEvent {
 		readRecordFunc = func(n int64) {
-			span.AddEvent("read", trace.WithAttributes(ReadBytesKey.Int64(n)))
+			events.addEvent("read", trace.WithAttributes(ReadBytesKey.Int64(n)))
 		}
 	}
 
@@ -151,11 +162,46 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	writeRecordFunc := func(int64) {}
 	if h.writeEvent {
 		writeRecordFunc = func(n int64) {
-			span.AddEvent("write", trace.WithAttributes(WroteBytesKey.Int64(n)))
+			events.addEvent("write", trace.WithAttributes(WroteBytesKey.Int64(n)))
 		}
 	}
 
 	rww := request.NewRespWriterWrapper(w, writeRecordFunc)
+	rww.Upgrade = isUpgrade(r.Header)
+	if h.flushEvent {
+		rww.OnFlush = func() {
+			events.addEvent("flush")
+		}
+	}
+	if h.detectStreaming {
+		rww.OnStream = func(statusCode int) {
+			// End the request span now, a long-lived stream would otherwise
+			// produce a span lasting as long as the connection.
+			if r.Pattern != "" {
+				span.SetName(h.spanNameFormatter(h.operation, r))
+			}
+			// The status code of a hijacked connection may be unknown.
+			if statusCode > 0 {
+				span.SetStatus(h.semconv.Status(statusCode))
+			}
+			span.SetAttributes(h.semconv.ResponseTraceAttrs(semconv.ResponseTelemetry{
+				StatusCode: statusCode,
+				ReadBytes:  bw.BytesRead(),
+				ReadError:  bw.Error(),
+			})...)
+
+			var streamSpan trace.Span
+			if h.readEvent || h.writeEvent || h.flushEvent {
+				_, streamSpan = tracer.Start(
+					trace.ContextWithSpan(ctx, span),
+					h.spanNameFormatter(h.operation, r)+" stream",
+					trace.WithSpanKind(trace.SpanKindInternal),
+				)
+			}
+			events.swap(streamSpan)
+			span.End()
+		}
+	}
 
 	// Wrap w to use our ResponseWriter methods while also exposing
 	// other interfaces that w may implement (http.CloseNotifier,
@@ -174,6 +220,9 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 		Flush: func(httpsnoop.FlushFunc) httpsnoop.FlushFunc {
 			return rww.Flush
 		},
+		Hijack: func(httpsnoop.HijackFunc) httpsnoop.HijackFunc {
+			return rww.Hijack
+		},
 	})
 
 	labeler, found := LabelerFromContext(ctx)
@@ -184,25 +233,31 @@ func (h *middleware) serveHTTP(w http.ResponseWriter, r *http.Request, next http
 	r = r.WithContext(ctx)
 	next.ServeHTTP(w, r)
 
-	if r.Pattern != "" {
-		span.SetName(h.spanNameFormatter(h.operation, r))
-	}
-
 	statusCode := rww.StatusCode()
 	bytesWritten := rww.BytesWritten()
-	span.SetStatus(h.semconv.Status(statusCode))
 	bytesRead := bw.BytesRead()
-	span.SetAttributes(h.semconv.ResponseTraceAttrs(semconv.ResponseTelemetry{
-		StatusCode: statusCode,
-		ReadBytes:  bytesRead,
-		ReadError:  bw.Error(),
-		WriteBytes: bytesWritten,
-		WriteError: rww.Error(),
-	})...)
+	streaming := h.detectStreaming && rww.Streaming()
+	if !streaming {
+		if r.Pattern != "" {
+			span.SetName(h.spanNameFormatter(h.operation, r))
+		}
+
+		if statusCode > 0 {
+			span.SetStatus(h.semconv.Status(statusCode))
+		}
+		span.SetAttributes(h.semconv.ResponseTraceAttrs(semconv.ResponseTelemetry{
+			StatusCode: statusCode,
+			ReadBytes:  bytesRead,
+			ReadError:  bw.Error(),
+			WriteBytes: bytesWritten,
+			WriteError: rww.Error(),
+		})...)
+	}
 
 	h.semconv.RecordMetrics(ctx, semconv.ServerMetricData{
 		ServerName:   h.server,
 		ResponseSize: bytesWritten,
+		Streaming:    streaming,
 		MetricAttributes: semconv.MetricAttributes{
 			Req:                  r,
 			StatusCode:           statusCode,
@@ -222,3 +277,53 @@ func (h *middleware) metricAttributesFromRequest(r *http.Request) []attribute.Ke
 	}
 	return attributeForRequest
 }
+
+// isUpgrade returns whether the "Connection" header of h holds the "Upgrade"
+// token.
+func isUpgrade(h http.Header) bool {
+	for _, v := range h.Values("Connection") {
+		for token := range strings.SplitSeq(v, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// eventSpan is the span message events are recorded on. It is safe for
+// concurrent use.
+type eventSpan struct {
+	mu     sync.Mutex
+	span   trace.Span
+	stream bool
+}
+
+func (e *eventSpan) addEvent(name string, opts ...trace.EventOption) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.span != nil {
+		e.span.AddEvent(name, opts...)
+	}
+}
+
+// swap replaces the span events are recorded on with the stream span. A nil
+// span drops all subsequent events.
+func (e *eventSpan) swap(span trace.Span) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.span = span
+	e.stream = true
+}
+
+// end ends the stream span, if any.
+func (e *eventSpan) end() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stream && e.span != nil {
+		e.span.End()
+	}
+}
@@ -791,3 +791,195 @@ func BenchmarkHandlerServeHTTP(b *testing.B) {
 		})
 	}
 }
+
+func TestHandlerStreamingDetection(t *testing.T) {
+	testCases := []struct {
+		name            string
+		opts            []Option
+		wantEarlyEnd    bool
+		wantSpanNames   []string
+		wantDurationRec bool
+	}{
+		{
+			name:            "disabled",
+			wantSpanNames:   []string{"GET /events"},
+			wantDurationRec: true,
+		},
+		{
+			name:          "enabled",
+			opts:          []Option{WithStreamingDetection()},
+			wantEarlyEnd:  true,
+			wantSpanNames: []string{"GET /events"},
+		},
+		{
+			name: "enabled with message events",
+			opts: []Option{
+				WithStreamingDetection(),
+				WithMessageEvents(WriteEvents, FlushEvents),
+			},
+			wantEarlyEnd:  true,
+			wantSpanNames: []string{"GET /events", "GET /events stream"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := sdkmetric.NewManualReader()
+			meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			var endedDuringStream int
+			mux := http.NewServeMux()
+			mux.HandleFunc("/events", func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/event-stream")
+				for i := range 3 {
+					_, _ = fmt.Fprintf(w, "data: %d\n\n", i)
+					w.(http.Flusher).Flush()
+				}
+				endedDuringStream = len(spanRecorder.Ended())
+			})
+
+			opts := append([]Option{
+				WithTracerProvider(provider),
+				WithMeterProvider(meterProvider),
+			}, tc.opts...)
+			h := NewHandler(mux, "test_handler", opts...)
+
+			r, err := http.NewRequestWithContext(t.Context(), http.MethodGet, "http://localhost/events", http.NoBody)
+			require.NoError(t, err)
+			rr := httptest.NewRecorder()
+			h.ServeHTTP(rr, r)
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+			if tc.wantEarlyEnd {
+				assert.Equal(t, 1, endedDuringStream, "request span not ended at stream start")
+			} else {
+				assert.Equal(t, 0, endedDuringStream)
+			}
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, len(tc.wantSpanNames))
+			for i, name := range tc.wantSpanNames {
+				assert.Equal(t, name, spans[i].Name())
+			}
+			assert.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+
+			if len(spans) > 1 {
+				stream := spans[1]
+				assert.Equal(t, spans[0].SpanContext().SpanID(), stream.Parent().SpanID())
+				var names []string
+				for _, e := range stream.Events() {
+					names = append(names, e.Name)
+				}
+				assert.Equal(t, []string{"write", "flush", "write", "flush", "write", "flush"}, names)
+			}
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(t.Context(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			var gotDuration bool
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				if m.Name == "http.server.request.duration" {
+					gotDuration = true
+				}
+			}
+			assert.Equal(t, tc.wantDurationRec, gotDuration)
+		})
+	}
+}
+
+func TestHandlerStreamingDetectionHijack(t *testing.T) {
+	tests := []struct {
+		name       string
+		upgrade    bool
+		response   string
+		wantStatus int
+	}{
+		{
+			name:       "upgrade",
+			upgrade:    true,
+			response:   "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n",
+			wantStatus: http.StatusSwitchingProtocols,
+		},
+		{
+			name:     "no upgrade",
+			response: "HTTP/1.1 204 No Content\r\n\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spanRecorder := tracetest.NewSpanRecorder()
+			provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+			reader := sdkmetric.NewManualReader()
+			meterProvider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+
+			endedAfterHijack := make(chan int, 1)
+			h := NewHandler(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				conn, buf, err := w.(http.Hijacker).Hijack()
+				if !assert.NoError(t, err) {
+					return
+				}
+				defer conn.Close()
+				endedAfterHijack <- len(spanRecorder.Ended())
+
+				_, _ = buf.WriteString(tt.response)
+				_ = buf.Flush()
+			}), "test_handler",
+				WithTracerProvider(provider),
+				WithMeterProvider(meterProvider),
+				WithStreamingDetection(),
+			)
+
+			done := make(chan struct{})
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer close(done)
+				h.ServeHTTP(w, r)
+			}))
+			defer srv.Close()
+
+			req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, srv.URL, http.NoBody)
+			require.NoError(t, err)
+			if tt.upgrade {
+				req.Header.Set("Connection", "Upgrade")
+				req.Header.Set("Upgrade", "websocket")
+			}
+			resp, err := http.DefaultClient.Do(req)
+			require.NoError(t, err)
+			_ = resp.Body.Close()
+
+			assert.Equal(t, 1, <-endedAfterHijack, "request span not ended at hijack")
+			<-done
+
+			spans := spanRecorder.Ended()
+			require.Len(t, spans, 1)
+			assert.Equal(t, codes.Unset, spans[0].Status().Code)
+			var gotStatus int
+			for _, kv := range spans[0].Attributes() {
+				if kv.Key == "http.response.status_code" {
+					gotStatus = int(kv.Value.AsInt64())
+				}
+			}
+			assert.Equal(t, tt.wantStatus, gotStatus)
+
+			rm := metricdata.ResourceMetrics{}
+			require.NoError(t, reader.Collect(t.Context(), &rm))
+			require.Len(t, rm.ScopeMetrics, 1)
+			var names []string
+			for _, m := range rm.ScopeMetrics[0].Metrics {
+				names = append(names, m.Name)
+			}
+			assert.Contains(t, names, "http.server.request.body.size")
+			assert.NotContains(t, names, "http.server.request.duration")
+		})
+	}
+}
+
+func TestIsUpgrade(t *testing.T) {
+	assert.True(t, isUpgrade(http.Header{"Connection": {"Upgrade"}}))
+	assert.True(t, isUpgrade(http.Header{"Connection": {"keep-alive, upgrade"}}))
+	assert.True(t, isUpgrade(http.Header{"Connection": {"keep-alive", "Upgrade"}}))
+	assert.False(t, isUpgrade(http.Header{"Connection": {"keep-alive"}}))
+	assert.False(t, isUpgrade(http.Header{}))
+}
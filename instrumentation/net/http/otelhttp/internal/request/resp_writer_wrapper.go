@@ -7,7 +7,10 @@
 package request
 
 import (
+	"bufio"
+	"net"
 	"net/http"
+	"strings"
 	"sync"
 )
 
@@ -15,18 +18,36 @@ var _ http.ResponseWriter = &RespWriterWrapper{}
 
 // RespWriterWrapper wraps a http.ResponseWriter in order to track the number of
 // bytes written, the last error, and to catch the first written statusCode.
+// It also detects long-lived streaming responses: connections that are
+// hijacked or switch protocols, and Server-Sent Events responses.
 // TODO: The wrapped http.ResponseWriter doesn't implement any of the optional
-// types (http.Hijacker, http.Pusher, http.CloseNotifier, etc)
+// types (http.Pusher, http.CloseNotifier, etc)
 // that may be useful when using it in real life situations.
 type RespWriterWrapper struct {
 	http.ResponseWriter
 	OnWrite func(n int64) // must not be nil
 
+	// OnFlush, if not nil, is called every time the response is flushed.
+	OnFlush func()
+	// OnStream, if not nil, is called once with the response status code
+	// when the response is detected to be a long-lived stream.
+	//
+	// It is called while the wrapper is locked and must not call any of the
+	// wrapper methods.
+	OnStream func(statusCode int)
+	// Upgrade reports whether the request asks to upgrade the connection
+	// with the "Connection: Upgrade" header. The response to such a request
+	// written on a hijacked connection is assumed to be a 101 Switching
+	// Protocols response.
+	Upgrade bool
+
 	mu          sync.RWMutex
 	written     int64
 	statusCode  int
 	err         error
 	wroteHeader bool
+	streaming   bool
+	hijacked    bool
 }
 
 // NewRespWriterWrapper creates a new RespWriterWrapper.
@@ -86,10 +107,37 @@ func (w *RespWriterWrapper) writeHeader(statusCode int) {
 
 		w.wroteHeader = true
 		w.statusCode = statusCode
+		if statusCode == http.StatusSwitchingProtocols || isEventStream(w.ResponseWriter.Header()) {
+			w.stream()
+		}
 	}
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// isEventStream returns whether the header describes a Server-Sent Events
+// response.
+func isEventStream(h http.Header) bool {
+	ct := h.Get("Content-Type")
+	if i := strings.IndexByte(ct, ';'); i >= 0 {
+		ct = ct[:i]
+	}
+	return strings.EqualFold(strings.TrimSpace(ct), "text/event-stream")
+}
+
+// stream marks the response as a long-lived stream and calls OnStream the
+// first time it is called.
+// It does not acquire a lock, and therefore assumes that is being handled by a
+// parent method.
+func (w *RespWriterWrapper) stream() {
+	if w.streaming {
+		return
+	}
+	w.streaming = true
+	if w.OnStream != nil {
+		w.OnStream(w.statusCode)
+	}
+}
+
 // Flush implements [http.Flusher].
 func (w *RespWriterWrapper) Flush() {
 	w.mu.Lock()
@@ -102,6 +150,43 @@ func (w *RespWriterWrapper) Flush() {
 	if f, ok := w.ResponseWriter.(http.Flusher); ok {
 		f.Flush()
 	}
+
+	if w.OnFlush != nil {
+		w.OnFlush()
+	}
+}
+
+// Hijack implements [http.Hijacker]. A successfully hijacked connection is
+// considered a long-lived stream.
+//
+// If no status code was written before, the response is written on the
+// hijacked connection and its status code is unknown. It is then only
+// recorded as 101 Switching Protocols when the request asks for an
+// upgrade, see Upgrade, and left unset otherwise.
+func (w *RespWriterWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	conn, rw, err := h.Hijack()
+	if err != nil {
+		return conn, rw, err
+	}
+
+	w.hijacked = true
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.statusCode = 0
+		if w.Upgrade {
+			w.statusCode = http.StatusSwitchingProtocols
+		}
+	}
+	w.stream()
+	return conn, rw, nil
 }
 
 // BytesWritten returns the number of bytes written.
@@ -112,7 +197,8 @@ func (w *RespWriterWrapper) BytesWritten() int64 {
 	return w.written
 }
 
-// StatusCode returns the HTTP status code that was sent.
+// StatusCode returns the HTTP status code that was sent, or 0 if it is
+// unknown because the connection was hijacked.
 func (w *RespWriterWrapper) StatusCode() int {
 	w.mu.RLock()
 	defer w.mu.RUnlock()
@@ -120,6 +206,23 @@ func (w *RespWriterWrapper) StatusCode() int {
 	return w.statusCode
 }
 
+// Streaming returns whether the response was detected to be a long-lived
+// stream.
+func (w *RespWriterWrapper) Streaming() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.streaming
+}
+
+// Hijacked returns whether the underlying connection was hijacked.
+func (w *RespWriterWrapper) Hijacked() bool {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.hijacked
+}
+
 // Error returns the last error.
 func (w *RespWriterWrapper) Error() error {
 	w.mu.RLock()
@@ -0,0 +1,218 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	otelsemconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp/internal/semconv"
+)
+
+type resendCountContextKeyType int
+
+const resendCountContextKey resendCountContextKeyType = 0
+
+// ContextWithResendCount returns a new context with the provided resend
+// count. The resend count is the number of times the request was already
+// sent: 0 for the first attempt, 1 for the first retry, and so on. It is
+// recorded as the http.request.resend_count attribute by a [Transport]
+// configured with [WithResendCount]. Injecting it multiple times will
+// override the previous calls.
+func ContextWithResendCount(parent context.Context, n int) context.Context {
+	return context.WithValue(parent, resendCountContextKey, n)
+}
+
+// ResendCountFromContext retrieves the resend count from the provided
+// context if one is available. If no resend count was found in the provided
+// context, 0 is returned.
+func ResendCountFromContext(ctx context.Context) int {
+	n, _ := ctx.Value(resendCountContextKey).(int)
+	return n
+}
+
+// resendCount returns the number of times r was already sent. It sums the
+// resend count stored in the request context and the number of redirects
+// http.Client followed to produce r.
+func resendCount(r *http.Request) int {
+	n := ResendCountFromContext(r.Context())
+	for res := r.Response; res != nil && res.Request != nil; res = res.Request.Response {
+		n++
+	}
+	return n
+}
+
+// RetryPolicy decides whether a request is re-sent. It is called with the
+// attempt number, starting at 0, and the response and error returned by that
+// attempt. It returns whether the request should be re-sent, and how long to
+// wait before doing so.
+type RetryPolicy func(attempt int, res *http.Response, err error) (retry bool, backoff time.Duration)
+
+// RetryTransport implements the http.RoundTripper interface and re-sends
+// outbound HTTP(S) requests according to a RetryPolicy. All attempts are
+// wrapped in a single span representing the logical operation, and the
+// attempt number is propagated to the base http.RoundTripper with
+// [ContextWithResendCount].
+//
+// The base http.RoundTripper is expected to be a [Transport] so that every
+// attempt is recorded as a child span of the operation span.
+type RetryTransport struct {
+	rt     http.RoundTripper
+	policy RetryPolicy
+
+	tracer            trace.Tracer
+	spanStartOptions  []trace.SpanStartOption
+	spanNameFormatter func(string, *http.Request) string
+
+	semconv semconv.HTTPClient
+}
+
+var _ http.RoundTripper = &RetryTransport{}
+
+// NewRetryTransport wraps the provided http.RoundTripper with one that
+// re-sends requests according to policy and starts a span for the logical
+// operation that spans all attempts.
+//
+// A request is only re-sent if it has no body or its GetBody field is set.
+// If policy is nil, requests are never re-sent. If the provided
+// http.RoundTripper is nil, a [Transport] wrapping http.DefaultTransport
+// configured with opts and [WithResendCount] is used.
+func NewRetryTransport(base http.RoundTripper, policy RetryPolicy, opts ...Option) *RetryTransport {
+	if base == nil {
+		base = NewTransport(nil, append(slices.Clip(opts), WithResendCount())...)
+	}
+	if policy == nil {
+		policy = func(int, *http.Response, error) (bool, time.Duration) {
+			return false, 0
+		}
+	}
+
+	t := RetryTransport{
+		rt:     base,
+		policy: policy,
+	}
+
+	defaultOpts := []Option{
+		WithSpanOptions(trace.WithSpanKind(trace.SpanKindInternal)),
+		WithSpanNameFormatter(defaultTransportFormatter),
+	}
+
+	c := newConfig(append(defaultOpts, opts...)...)
+	t.tracer = c.Tracer
+	t.spanStartOptions = c.SpanStartOptions
+	t.spanNameFormatter = c.SpanNameFormatter
+
+	return &t
+}
+
+// RoundTrip creates a span for the logical operation and sends the request
+// to the base RoundTripper until the RetryPolicy stops retrying. The created
+// span will end when the body of the returned response is closed or when a
+// read from the body returns io.EOF.
+func (t *RetryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	tracer := t.tracer
+	if tracer == nil {
+		if span := trace.SpanFromContext(r.Context()); span.SpanContext().IsValid() {
+			tracer = newTracer(span.TracerProvider())
+		} else {
+			tracer = newTracer(otel.GetTracerProvider())
+		}
+	}
+
+	ctx, span := tracer.Start(r.Context(), t.spanNameFormatter("", r), t.spanStartOptions...)
+	span.SetAttributes(t.semconv.RequestTraceAttrs(r)...)
+
+	var (
+		res     *http.Response
+		err     error
+		attempt int
+	)
+	for ; ; attempt++ {
+		req := r.Clone(ContextWithResendCount(ctx, attempt))
+		if attempt > 0 && req.Body != nil && req.Body != http.NoBody {
+			// The previous attempt consumed the body. GetBody is known to be
+			// set as it is checked before retrying.
+			req.Body, err = r.GetBody()
+			if err != nil {
+				res = nil
+				break
+			}
+		}
+
+		res, err = t.rt.RoundTrip(req)
+
+		retry, backoff := t.policy(attempt, res, err)
+		if !retry || !rewindable(r) {
+			break
+		}
+		if res != nil && res.Body != nil {
+			// Drain the body so the connection can be reused.
+			_, _ = io.Copy(io.Discard, res.Body)
+			_ = res.Body.Close()
+		}
+
+		if err = wait(ctx, backoff); err != nil {
+			res = nil
+			break
+		}
+	}
+
+	if attempt > 0 {
+		span.SetAttributes(otelsemconv.HTTPRequestResendCount(attempt))
+	}
+
+	if err != nil {
+		span.SetAttributes(otelsemconv.ErrorType(err))
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+
+		return res, err
+	}
+
+	res, err = ensureResponseBody(t.rt, r, res)
+	if err != nil {
+		span.SetAttributes(otelsemconv.ErrorType(err))
+		span.SetStatus(codes.Error, err.Error())
+		span.End()
+
+		return res, err
+	}
+
+	res.Body = newWrappedBody(span, func(int64) {}, res.Body)
+	span.SetAttributes(t.semconv.ResponseTraceAttrs(res)...)
+	span.SetStatus(t.semconv.Status(res.StatusCode))
+
+	return res, nil
+}
+
+// rewindable returns whether the body of r can be sent again.
+func rewindable(r *http.Request) bool {
+	return r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+}
+
+// wait blocks for d or until ctx is done, in which case the context error is
+// returned.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package otelhttp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.43.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func retryOn5xx(maxAttempts int) RetryPolicy {
+	return func(attempt int, res *http.Response, err error) (bool, time.Duration) {
+		if attempt+1 >= maxAttempts {
+			return false, 0
+		}
+		return err != nil || res.StatusCode >= http.StatusInternalServerError, time.Millisecond
+	}
+}
+
+func resendCountAttr(t *testing.T, span sdktrace.ReadOnlySpan) (int64, bool) {
+	t.Helper()
+	for _, kv := range span.Attributes() {
+		if kv.Key == semconv.HTTPRequestResendCountKey {
+			return kv.Value.AsInt64(), true
+		}
+	}
+	return 0, false
+}
+
+func TestResendCountFromContext(t *testing.T) {
+	ctx := t.Context()
+	assert.Equal(t, 0, ResendCountFromContext(ctx))
+
+	ctx = ContextWithResendCount(ctx, 2)
+	assert.Equal(t, 2, ResendCountFromContext(ctx))
+}
+
+func TestRetryTransport(t *testing.T) {
+	content := []byte("Hello, world!")
+
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, content, body)
+
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(content)
+	}))
+	defer ts.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	tr := NewRetryTransport(
+		NewTransport(http.DefaultTransport, WithTracerProvider(tp), WithResendCount()),
+		retryOn5xx(5),
+		WithTracerProvider(tp),
+	)
+	c := http.Client{Transport: tr}
+
+	r, err := http.NewRequestWithContext(t.Context(), http.MethodPost, ts.URL, bytes.NewReader(content))
+	require.NoError(t, err)
+	res, err := c.Do(r)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, content, body)
+	assert.Equal(t, int32(3), calls.Load())
+
+	spans := sr.Ended()
+	require.Len(t, spans, 4)
+
+	op := spans[len(spans)-1]
+	assert.Equal(t, "HTTP POST", op.Name())
+	assert.Equal(t, trace.SpanKindInternal, op.SpanKind())
+	assert.Equal(t, codes.Unset, op.Status().Code)
+	n, ok := resendCountAttr(t, op)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), n)
+
+	for i, attempt := range spans[:3] {
+		assert.Equal(t, trace.SpanKindClient, attempt.SpanKind())
+		assert.Equal(t, op.SpanContext().SpanID(), attempt.Parent().SpanID())
+
+		n, ok := resendCountAttr(t, attempt)
+		if i == 0 {
+			assert.False(t, ok, "first attempt has a resend count")
+			continue
+		}
+		assert.True(t, ok)
+		assert.Equal(t, int64(i), n)
+	}
+}
+
+func TestRetryTransportNoRetry(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	c := http.Client{Transport: NewRetryTransport(nil, nil, WithTracerProvider(tp))}
+	r, err := http.NewRequestWithContext(t.Context(), http.MethodGet, ts.URL, http.NoBody)
+	require.NoError(t, err)
+	res, err := c.Do(r)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	for _, s := range spans {
+		_, ok := resendCountAttr(t, s)
+		assert.False(t, ok)
+		assert.Equal(t, codes.Error, s.Status().Code)
+	}
+}
+
+func TestNewRetryTransportOptionsNotModified(t *testing.T) {
+	opts := make([]Option, 1, 2)
+	opts[0] = WithTracerProvider(sdktrace.NewTracerProvider())
+
+	NewRetryTransport(nil, nil, opts...)
+	assert.Nil(t, opts[:2][1], "spare capacity of the options should not be written")
+}
+
+func TestRetryTransportContextCanceled(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	policy := func(int, *http.Response, error) (bool, time.Duration) {
+		cancel()
+		return true, time.Hour
+	}
+	c := http.Client{Transport: NewRetryTransport(nil, policy, WithTracerProvider(tp))}
+
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, http.NoBody)
+	require.NoError(t, err)
+	_, err = c.Do(r) //nolint:bodyclose // The request fails.
+	require.ErrorIs(t, err, context.Canceled)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	op := spans[1]
+	assert.Equal(t, codes.Error, op.Status().Code)
+	assert.Contains(t, op.Attributes(), attribute.String("error.type", "*errors.errorString"))
+}
+
+func TestRetryTransportBodyNotRewindable(t *testing.T) {
+	var calls atomic.Int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	tr := NewRetryTransport(nil, retryOn5xx(3))
+
+	r, err := http.NewRequestWithContext(t.Context(), http.MethodPost, ts.URL, io.NopCloser(bytes.NewReader([]byte("body"))))
+	require.NoError(t, err)
+	require.Nil(t, r.GetBody)
+
+	res, err := tr.RoundTrip(r)
+	require.NoError(t, err)
+	require.NoError(t, res.Body.Close())
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestTransportResendCountRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/old", http.RedirectHandler("/mid", http.StatusFound))
+	mux.Handle("/mid", http.RedirectHandler("/new", http.StatusFound))
+	mux.HandleFunc("/new", func(http.ResponseWriter, *http.Request) {})
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		name string
+		opts []Option
+		want []int64
+	}{
+		{
+			name: "disabled",
+			want: []int64{0, 0, 0},
+		},
+		{
+			name: "enabled",
+			opts: []Option{WithResendCount()},
+			want: []int64{0, 1, 2},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+			c := http.Client{Transport: NewTransport(http.DefaultTransport, append(tc.opts, WithTracerProvider(tp))...)}
+			r, err := http.NewRequestWithContext(t.Context(), http.MethodGet, ts.URL+"/old", http.NoBody)
+			require.NoError(t, err)
+			res, err := c.Do(r)
+			require.NoError(t, err)
+			require.NoError(t, res.Body.Close())
+
+			spans := sr.Ended()
+			require.Len(t, spans, len(tc.want))
+			for i, want := range tc.want {
+				got, _ := resendCountAttr(t, spans[i])
+				assert.Equal(t, want, got, "span %d", i)
+			}
+		})
+	}
+}
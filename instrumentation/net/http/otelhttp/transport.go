@@ -35,6 +35,7 @@ type Transport struct {
 	spanNameFormatter  func(string, *http.Request) string
 	clientTrace        func(context.Context) *httptrace.ClientTrace
 	metricAttributesFn func(*http.Request) []attribute.KeyValue
+	resendCount        bool
 
 	semconv semconv.HTTPClient
 }
@@ -76,6 +77,7 @@ func (t *Transport) applyConfig(c *config) {
 	t.clientTrace = c.ClientTrace
 	t.semconv = semconv.NewHTTPClient(c.Meter)
 	t.metricAttributesFn = c.MetricAttributesFn
+	t.resendCount = c.ResendCount
 }
 
 func defaultTransportFormatter(_ string, r *http.Request) string {
@@ -140,6 +142,11 @@ func (t *Transport) RoundTrip(r *http.Request) (*http.Response, error) {
 	}
 
 	span.SetAttributes(t.semconv.RequestTraceAttrs(r)...)
+	if t.resendCount {
+		if n := resendCount(r); n > 0 {
+			span.SetAttributes(otelsemconv.HTTPRequestResendCount(n))
+		}
+	}
 	t.propagators.Inject(ctx, propagation.HeaderCarrier(r.Header))
 
 	res, err := t.rt.RoundTrip(r)
@@ -5,6 +5,7 @@ package otelhttp
 
 import (
 	"net/http"
+	"time"
 )
 
 func ExampleNewTransport() {
@@ -14,3 +15,22 @@ func ExampleNewTransport() {
 		Transport: NewTransport(http.DefaultTransport),
 	}
 }
+
+func ExampleNewRetryTransport() {
+	// Retry requests failing with a 503 Service Unavailable status code up
+	// to two times. Every attempt is recorded as a child span of the span
+	// representing the logical operation.
+	policy := func(attempt int, res *http.Response, err error) (bool, time.Duration) {
+		if attempt >= 2 || err != nil {
+			return false, 0
+		}
+		return res.StatusCode == http.StatusServiceUnavailable, 100 * time.Millisecond
+	}
+
+	_ = http.Client{
+		Transport: NewRetryTransport(
+			NewTransport(http.DefaultTransport, WithResendCount()),
+			policy,
+		),
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
 )
 
 // config contains optional settings for reporting runtime metrics.
@@ -20,6 +21,15 @@ type config struct {
 	// MeterProvider sets the metric.MeterProvider.  If nil, the global
 	// Provider will be used.
 	MeterProvider metric.MeterProvider
+
+	// GCMetrics enables the garbage collector metrics.
+	GCMetrics bool
+	// MutexMetrics enables the sync.Mutex contention metrics.
+	MutexMetrics bool
+	// CgoMetrics enables the cgo call metrics.
+	CgoMetrics bool
+	// FinalizerMetrics enables the finalizer queue metrics.
+	FinalizerMetrics bool
 }
 
 // Option supports configuring optional settings for runtime metrics.
@@ -72,6 +82,75 @@ func (o metricProviderOption) apply(c *config) {
 	}
 }
 
+// WithGCMetrics enables the reporting of garbage collector metrics.
+//
+// With Start, the following metrics are emitted:
+//
+//	go.memory.gc.cycles  {gc_cycle}  Number of completed GC cycles.
+//	memory.heap.objects  {object}    Count of live and unswept objects occupying heap memory.
+//
+// With NewProducer, the following metrics are emitted:
+//
+//	go.memory.gc.pause.duration  s   Distribution of individual GC-related stop-the-world pause latencies.
+//	memory.allocation.size       By  Distribution of the size of heap allocations, approximately by size class.
+//
+// The memory.heap.objects and memory.allocation.size metrics are not defined
+// by the semantic conventions. They are experimental and may be renamed or
+// removed in a future release.
+func WithGCMetrics() ProducerOption {
+	return gcMetricsOption{}
+}
+
+type gcMetricsOption struct{}
+
+func (gcMetricsOption) apply(c *config) { c.GCMetrics = true }
+
+func (o gcMetricsOption) applyProducer(c *config) { o.apply(c) }
+
+// WithMutexMetrics enables the reporting of the time goroutines have spent
+// blocked on a sync.Mutex, sync.RWMutex or runtime-internal lock with Start:
+//
+//	sync.mutex.wait  s  Approximate cumulative time goroutines have spent blocked on a mutex.
+//
+// This metric is not defined by the semantic conventions. It is experimental
+// and may be renamed or removed in a future release.
+func WithMutexMetrics() Option {
+	return mutexMetricsOption{}
+}
+
+type mutexMetricsOption struct{}
+
+func (mutexMetricsOption) apply(c *config) { c.MutexMetrics = true }
+
+// WithCgoMetrics enables the reporting of calls from Go to C with Start:
+//
+//	cgo.calls  {call}  Count of calls made from Go to C by the current process.
+//
+// This metric is not defined by the semantic conventions. It is experimental
+// and may be renamed or removed in a future release.
+func WithCgoMetrics() Option {
+	return cgoMetricsOption{}
+}
+
+type cgoMetricsOption struct{}
+
+func (cgoMetricsOption) apply(c *config) { c.CgoMetrics = true }
+
+// WithFinalizerMetrics enables the reporting of the finalizer queue with
+// Start:
+//
+//	finalizer.queue.size  {finalizer}  Count of finalizers queued for execution but not yet executed.
+//
+// This metric is not defined by the semantic conventions. It is experimental
+// and may be renamed or removed in a future release.
+func WithFinalizerMetrics() Option {
+	return finalizerMetricsOption{}
+}
+
+type finalizerMetricsOption struct{}
+
+func (finalizerMetricsOption) apply(c *config) { c.FinalizerMetrics = true }
+
 // newConfig computes a config from the supplied Options.
 func newConfig(opts ...Option) config {
 	c := config{
@@ -97,3 +176,105 @@ func newProducerConfig(opts ...ProducerOption) config {
 	}
 	return c
 }
+
+// profileConfig contains optional settings for capturing profiles.
+type profileConfig struct {
+	// Interval is the interval between profile captures.
+	Interval time.Duration
+	// CPUProfileDuration is the duration of the CPU profile captured at the
+	// start of each interval.
+	CPUProfileDuration time.Duration
+	// Types are the types of profiles captured.
+	Types []ProfileType
+	// MutexProfileFraction is set as the mutex profile fraction of the
+	// runtime if positive.
+	MutexProfileFraction int
+	// Resource is the resource of the profiles.
+	Resource *resource.Resource
+}
+
+// ProfileOption supports configuring optional settings for capturing
+// profiles with a ProfileProducer.
+type ProfileOption interface {
+	applyProfile(*profileConfig)
+}
+
+type profileOptionFunc func(*profileConfig)
+
+func (o profileOptionFunc) applyProfile(c *profileConfig) { o(c) }
+
+// DefaultProfileInterval is the default interval between profile captures.
+// Use the WithProfileInterval() option to modify this setting in
+// NewProfileProducer().
+const DefaultProfileInterval time.Duration = time.Minute
+
+// DefaultCPUProfileDuration is the default duration of CPU profiles. Use the
+// WithCPUProfileDuration() option to modify this setting in
+// NewProfileProducer().
+const DefaultCPUProfileDuration time.Duration = 10 * time.Second
+
+// WithProfileInterval sets the interval between profile captures. This
+// setting is ignored when `d` is not positive.
+func WithProfileInterval(d time.Duration) ProfileOption {
+	return profileOptionFunc(func(c *profileConfig) {
+		if d > 0 {
+			c.Interval = d
+		}
+	})
+}
+
+// WithCPUProfileDuration sets the duration of the CPU profile captured at
+// the start of each interval. It is limited to the profile interval. This
+// setting is ignored when `d` is not positive.
+func WithCPUProfileDuration(d time.Duration) ProfileOption {
+	return profileOptionFunc(func(c *profileConfig) {
+		if d > 0 {
+			c.CPUProfileDuration = d
+		}
+	})
+}
+
+// WithProfileTypes sets the types of profiles captured. By default, all the
+// profile types are captured.
+func WithProfileTypes(types ...ProfileType) ProfileOption {
+	return profileOptionFunc(func(c *profileConfig) {
+		c.Types = types
+	})
+}
+
+// WithMutexProfileFraction sets the fraction of the mutex contention events
+// reported in the mutex profile with runtime.SetMutexProfileFraction when
+// the ProfileProducer is created. The fraction is process-wide, the previous
+// fraction is restored when the ProfileProducer is shut down. This setting is
+// ignored when `rate` is not positive, the mutex profile is then empty unless
+// the fraction is set by the application.
+func WithMutexProfileFraction(rate int) ProfileOption {
+	return profileOptionFunc(func(c *profileConfig) {
+		c.MutexProfileFraction = rate
+	})
+}
+
+// WithProfileResource sets the resource of the profiles. If this option is
+// not used, resource.Default() is used.
+func WithProfileResource(res *resource.Resource) ProfileOption {
+	return profileOptionFunc(func(c *profileConfig) {
+		if res != nil {
+			c.Resource = res
+		}
+	})
+}
+
+// newProfileConfig computes a profileConfig from the supplied ProfileOptions.
+func newProfileConfig(opts ...ProfileOption) profileConfig {
+	c := profileConfig{
+		Interval:           DefaultProfileInterval,
+		CPUProfileDuration: DefaultCPUProfileDuration,
+		Types:              []ProfileType{ProfileCPU, ProfileHeap, ProfileGoroutine, ProfileMutex},
+		Resource:           resource.Default(),
+	}
+	for _, opt := range opts {
+		opt.applyProfile(&c)
+	}
+	c.CPUProfileDuration = min(c.CPUProfileDuration, c.Interval)
+	return c
+}
@@ -30,6 +30,17 @@ func TestNewConfig(t *testing.T) {
 			opts:   []Option{WithMinimumReadMemStatsInterval(10 * time.Second)},
 			expect: config{MinimumReadMemStatsInterval: 10 * time.Second},
 		},
+		{
+			name: "optional metrics",
+			opts: []Option{WithGCMetrics(), WithMutexMetrics(), WithCgoMetrics(), WithFinalizerMetrics()},
+			expect: config{
+				MinimumReadMemStatsInterval: 15 * time.Second,
+				GCMetrics:                   true,
+				MutexMetrics:                true,
+				CgoMetrics:                  true,
+				FinalizerMetrics:            true,
+			},
+		},
 	} {
 		t.Run(tt.name, func(t *testing.T) {
 			got := newConfig(tt.opts...)
@@ -40,5 +51,6 @@ func TestNewConfig(t *testing.T) {
 
 func configEqual(a, b config) bool {
 	// ignore MeterProvider
-	return a.MinimumReadMemStatsInterval == b.MinimumReadMemStatsInterval
+	a.MeterProvider, b.MeterProvider = nil, nil
+	return a == b
 }
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"math"
 	"runtime/metrics"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/instrumentation"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/goconv"
 )
 
 var startTime time.Time
@@ -29,6 +31,7 @@ var histogramMetrics = []string{goSchedLatencies}
 type Producer struct {
 	lock      sync.Mutex
 	collector *goCollector
+	gcMetrics bool
 }
 
 var _ metric.Producer = (*Producer)(nil)
@@ -38,25 +41,32 @@ var _ metric.Producer = (*Producer)(nil)
 // Metrics emitted by NewProducer include:
 //
 //	go.schedule.duration    s             The time goroutines have spent in the scheduler in a runnable state before actually running.
+//
+// Additional metrics are emitted when enabled with [WithGCMetrics].
 func NewProducer(opts ...ProducerOption) *Producer {
 	c := newProducerConfig(opts...)
+	metricNames := histogramMetrics
+	if c.GCMetrics {
+		metricNames = append(slices.Clone(metricNames), goGCPauses, goHeapAllocsBySize)
+	}
 	return &Producer{
-		collector: newCollector(c.MinimumReadMemStatsInterval, histogramMetrics),
+		collector: newCollector(c.MinimumReadMemStatsInterval, metricNames),
+		gcMetrics: c.GCMetrics,
 	}
 }
 
 // Produce returns precomputed histogram metrics from the go runtime, or an error if unsuccessful.
 func (p *Producer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
 	p.lock.Lock()
+	defer p.lock.Unlock()
 	p.collector.refresh()
-	schedHist := p.collector.getHistogram(goSchedLatencies)
-	p.lock.Unlock()
 	// Use the last collection time (which may or may not be now) for the timestamp.
-	histDp := convertRuntimeHistogram(schedHist, p.collector.lastCollect)
+	ts := p.collector.lastCollect
+	histDp := convertRuntimeHistogram(p.collector.getHistogram(goSchedLatencies), ts)
 	if len(histDp) == 0 {
 		return nil, errors.New("unable to obtain go.schedule.duration metric from the runtime")
 	}
-	return []metricdata.ScopeMetrics{
+	sm := []metricdata.ScopeMetrics{
 		{
 			Scope: instrumentation.Scope{
 				Name:    ScopeName,
@@ -74,7 +84,30 @@ func (p *Producer) Produce(context.Context) ([]metricdata.ScopeMetrics, error) {
 				},
 			},
 		},
-	}, nil
+	}
+	if p.gcMetrics {
+		sm[0].Metrics = append(sm[0].Metrics,
+			metricdata.Metrics{
+				Name:        goconv.MemoryGCPauseDuration{}.Name(),
+				Description: goconv.MemoryGCPauseDuration{}.Description(),
+				Unit:        goconv.MemoryGCPauseDuration{}.Unit(),
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  convertRuntimeHistogram(p.collector.getHistogram(goGCPauses), ts),
+				},
+			},
+			metricdata.Metrics{
+				Name:        "memory.allocation.size",
+				Description: "Distribution of the size of heap allocations, approximately by size class.",
+				Unit:        "By",
+				Data: metricdata.Histogram[float64]{
+					Temporality: metricdata.CumulativeTemporality,
+					DataPoints:  convertRuntimeHistogram(p.collector.getHistogram(goHeapAllocsBySize), ts),
+				},
+			},
+		)
+	}
+	return sm, nil
 }
 
 var emptySet = attribute.EmptySet()
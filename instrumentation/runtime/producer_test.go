@@ -4,6 +4,7 @@
 package runtime
 
 import (
+	"runtime"
 	"runtime/metrics"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata"
 	"go.opentelemetry.io/otel/sdk/metric/metricdata/metricdatatest"
+	"go.opentelemetry.io/otel/semconv/v1.43.0/goconv"
 )
 
 func TestNewProducer(t *testing.T) {
@@ -47,6 +49,26 @@ func TestNewProducer(t *testing.T) {
 	metricdatatest.AssertEqual(t, expectedScopeMetric, rm.ScopeMetrics[0], metricdatatest.IgnoreTimestamp(), metricdatatest.IgnoreValue())
 }
 
+func TestNewProducerWithGCMetrics(t *testing.T) {
+	runtime.GC()
+
+	reader := metric.NewManualReader(metric.WithProducer(NewProducer(WithGCMetrics())))
+	_ = metric.NewMeterProvider(metric.WithReader(reader))
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+	require.Len(t, rm.ScopeMetrics[0].Metrics, 3)
+
+	for i, name := range []string{"go.schedule.duration", goconv.MemoryGCPauseDuration{}.Name(), "memory.allocation.size"} {
+		m := rm.ScopeMetrics[0].Metrics[i]
+		assert.Equal(t, name, m.Name)
+		h, ok := m.Data.(metricdata.Histogram[float64])
+		require.True(t, ok)
+		require.Len(t, h.DataPoints, 1)
+		assert.Positive(t, h.DataPoints[0].Count, "metric %q should have observations", name)
+	}
+}
+
 func TestConvertRuntimeHistogram(t *testing.T) {
 	rh := &metrics.Float64Histogram{
 		Buckets: []float64{0, 1, 2, 3},
@@ -0,0 +1,404 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	collectorprofilespb "go.opentelemetry.io/proto/slim/otlp/collector/profiles/v1development"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	profilespb "go.opentelemetry.io/proto/slim/otlp/profiles/v1development"
+	resourcepb "go.opentelemetry.io/proto/slim/otlp/resource/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// ProfileType is a type of profile captured by a ProfileProducer.
+type ProfileType string
+
+const (
+	// ProfileCPU is the CPU profile, the stacks sampled while running on a
+	// CPU during the CPU profile duration.
+	ProfileCPU ProfileType = "cpu"
+	// ProfileHeap is the heap profile, the stacks of a sampling of the
+	// memory allocations.
+	ProfileHeap ProfileType = "heap"
+	// ProfileGoroutine is the goroutine profile, the stacks of all the
+	// current goroutines.
+	ProfileGoroutine ProfileType = "goroutine"
+	// ProfileMutex is the mutex profile, the stacks of the holders of
+	// contended mutexes. It is empty unless a mutex profile fraction is set,
+	// see [WithMutexProfileFraction].
+	ProfileMutex ProfileType = "mutex"
+)
+
+// Labels identifying the span active in a goroutine, set by DoWithSpanLabels.
+const (
+	traceIDLabel = "trace_id"
+	spanIDLabel  = "span_id"
+)
+
+// ProfileExporter exports profiles, for example to a file or an OTLP
+// endpoint.
+//
+// The profiles are messages of the development version of the OTLP profiles
+// protocol (opentelemetry.proto.profiles.v1development). This interface is
+// experimental and will change with the protocol.
+type ProfileExporter interface {
+	// Export exports the profiles of data. It must not retain data after
+	// it returns.
+	Export(ctx context.Context, data *profilespb.ProfilesData) error
+	// Shutdown flushes and releases the resources of the exporter.
+	Shutdown(ctx context.Context) error
+}
+
+// ProfileProducer periodically captures profiles of the Go runtime with
+// runtime/pprof and exports them in the OpenTelemetry profiles data model.
+//
+// The samples of CPU and goroutine profiles recorded while running a function
+// with [DoWithSpanLabels] are linked to the span of its context.
+type ProfileProducer struct {
+	cfg      profileConfig
+	exporter ProfileExporter
+
+	// prevMutexProfileFraction is the mutex profile fraction restored on
+	// shutdown, if the fraction was set by the ProfileProducer.
+	prevMutexProfileFraction int
+
+	cancel       context.CancelFunc
+	done         chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewProfileProducer returns a ProfileProducer capturing profiles every
+// profile interval and exporting them with exporter until it is shut down.
+//
+// By default, the CPU, heap, goroutine and mutex profiles are captured every
+// minute, and the CPU profile records the first 10 seconds of each interval.
+func NewProfileProducer(exporter ProfileExporter, opts ...ProfileOption) *ProfileProducer {
+	cfg := newProfileConfig(opts...)
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &ProfileProducer{
+		cfg:      cfg,
+		exporter: exporter,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	if cfg.MutexProfileFraction > 0 {
+		p.prevMutexProfileFraction = runtime.SetMutexProfileFraction(cfg.MutexProfileFraction)
+	}
+	go p.run(ctx)
+	return p
+}
+
+func (p *ProfileProducer) run(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		data, err := p.Collect(ctx)
+		if err != nil {
+			otel.Handle(err)
+		}
+		if data == nil || ctx.Err() != nil {
+			continue
+		}
+		if err := p.exporter.Export(ctx, data); err != nil {
+			otel.Handle(err)
+		}
+	}
+}
+
+// Collect captures the configured profiles once and returns them. It blocks
+// for the CPU profile duration if the CPU profile is captured, or until ctx
+// is done.
+//
+// Profiles that cannot be captured are omitted and their errors returned
+// together with the other profiles.
+func (p *ProfileProducer) Collect(ctx context.Context) (*profilespb.ProfilesData, error) {
+	var (
+		profiles []*profile.Profile
+		errs     []error
+	)
+	for _, t := range p.cfg.Types {
+		prof, err := p.capture(ctx, t)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to capture %s profile: %w", t, err))
+			continue
+		}
+		profiles = append(profiles, prof)
+	}
+	if len(profiles) == 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	b := newDictionaryBuilder()
+	var converted []*profilespb.Profile
+	for _, prof := range profiles {
+		converted = append(converted, b.convertProfile(prof, newProfileID)...)
+	}
+	return &profilespb.ProfilesData{
+		ResourceProfiles: []*profilespb.ResourceProfiles{{
+			Resource: &resourcepb.Resource{
+				Attributes: keyValues(p.cfg.Resource.Set()),
+			},
+			SchemaUrl: p.cfg.Resource.SchemaURL(),
+			ScopeProfiles: []*profilespb.ScopeProfiles{{
+				Scope: &commonpb.InstrumentationScope{
+					Name:    ScopeName,
+					Version: Version,
+				},
+				Profiles: converted,
+			}},
+		}},
+		Dictionary: b.dict,
+	}, errors.Join(errs...)
+}
+
+func (p *ProfileProducer) capture(ctx context.Context, t ProfileType) (*profile.Profile, error) {
+	var buf bytes.Buffer
+	switch t {
+	case ProfileCPU:
+		if err := pprof.StartCPUProfile(&buf); err != nil {
+			return nil, err
+		}
+		timer := time.NewTimer(p.cfg.CPUProfileDuration)
+		select {
+		case <-ctx.Done():
+		case <-timer.C:
+		}
+		timer.Stop()
+		pprof.StopCPUProfile()
+	case ProfileHeap, ProfileGoroutine, ProfileMutex:
+		if err := pprof.Lookup(string(t)).WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unsupported profile type")
+	}
+	return profile.Parse(&buf)
+}
+
+// Shutdown stops capturing profiles, restores the mutex profile fraction set
+// by [WithMutexProfileFraction] and shuts down the exporter. A capture in
+// progress is interrupted.
+//
+// The exporter is shut down even if ctx is done before the capture or export
+// in progress returns, the error of ctx is then returned along with the error
+// of the exporter.
+func (p *ProfileProducer) Shutdown(ctx context.Context) error {
+	err := errors.New("profile producer already shut down")
+	p.shutdownOnce.Do(func() {
+		p.cancel()
+		err = nil
+		select {
+		case <-p.done:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+		if p.cfg.MutexProfileFraction > 0 {
+			runtime.SetMutexProfileFraction(p.prevMutexProfileFraction)
+		}
+		err = errors.Join(err, p.exporter.Shutdown(ctx))
+	})
+	return err
+}
+
+func newProfileID() []byte {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return id
+}
+
+// DoWithSpanLabels calls f with a copy of ctx holding pprof labels that
+// identify the span of ctx, if it is sampled. The samples of the CPU and
+// goroutine profiles recorded while f runs, including those of the
+// goroutines it starts, are linked to the span by a ProfileProducer.
+func DoWithSpanLabels(ctx context.Context, f func(context.Context)) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsSampled() {
+		f(ctx)
+		return
+	}
+	pprof.Do(ctx, pprof.Labels(traceIDLabel, sc.TraceID().String(), spanIDLabel, sc.SpanID().String()), f)
+}
+
+type fileProfileExporter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewFileProfileExporter returns a ProfileExporter writing profiles to w in
+// the OTLP file exporter format: each export is written as one line of
+// OTLP/JSON encoded ProfilesData.
+//
+// The exporter does not close w.
+func NewFileProfileExporter(w io.Writer) ProfileExporter {
+	return &fileProfileExporter{w: w}
+}
+
+func (e *fileProfileExporter) Export(ctx context.Context, data *profilespb.ProfilesData) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	b, err := otlpJSON(data)
+	if err != nil {
+		return err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	_, err = e.w.Write(append(b, '\n'))
+	return err
+}
+
+func (*fileProfileExporter) Shutdown(context.Context) error { return nil }
+
+// otlpJSON returns the OTLP/JSON encoding of data. protojson encodes the
+// bytes fields in base64, the trace, span and profile IDs are re-encoded in
+// hex as required by OTLP/JSON.
+func otlpJSON(data *profilespb.ProfilesData) ([]byte, error) {
+	b, err := protojson.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	for _, rp := range objects(m, "resourceProfiles") {
+		for _, sp := range objects(rp, "scopeProfiles") {
+			for _, p := range objects(sp, "profiles") {
+				hexID(p, "profileId")
+			}
+		}
+	}
+	if dict, ok := m["dictionary"].(map[string]any); ok {
+		for _, l := range objects(dict, "linkTable") {
+			hexID(l, "traceId")
+			hexID(l, "spanId")
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(m); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// objects returns the objects of the array at key in obj.
+func objects(obj map[string]any, key string) []map[string]any {
+	arr, _ := obj[key].([]any)
+	out := make([]map[string]any, 0, len(arr))
+	for _, v := range arr {
+		if o, ok := v.(map[string]any); ok {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// hexID re-encodes the base64 encoded ID at key in obj in hex.
+func hexID(obj map[string]any, key string) {
+	s, ok := obj[key].(string)
+	if !ok {
+		return
+	}
+	if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+		obj[key] = hex.EncodeToString(b)
+	}
+}
+
+type otlpProfileExporter struct {
+	url     string
+	client  *http.Client
+	stopped atomic.Bool
+}
+
+// NewOTLPProfileExporter returns a ProfileExporter sending profiles with the
+// OTLP/HTTP binary protobuf encoding to url, the full URL of the profiles
+// endpoint, for example "http://localhost:4318/v1development/profiles". The
+// requests are sent with client, or http.DefaultClient if client is nil.
+//
+// The endpoint must support the development version of the OTLP profiles
+// protocol.
+func NewOTLPProfileExporter(url string, client *http.Client) ProfileExporter {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &otlpProfileExporter{url: url, client: client}
+}
+
+func (e *otlpProfileExporter) Export(ctx context.Context, data *profilespb.ProfilesData) error {
+	if e.stopped.Load() {
+		return errors.New("profile exporter shut down")
+	}
+	body, err := proto.Marshal(&collectorprofilespb.ExportProfilesServiceRequest{
+		ResourceProfiles: data.GetResourceProfiles(),
+		Dictionary:       data.GetDictionary(),
+	})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("failed to export profiles: %s", resp.Status)
+	}
+
+	var r collectorprofilespb.ExportProfilesServiceResponse
+	if err := proto.Unmarshal(respBody, &r); err != nil {
+		return fmt.Errorf("failed to decode export profiles response: %w", err)
+	}
+	if ps := r.GetPartialSuccess(); ps.GetRejectedProfiles() > 0 {
+		return fmt.Errorf("%d profiles rejected: %s", ps.GetRejectedProfiles(), ps.GetErrorMessage())
+	}
+	return nil
+}
+
+func (e *otlpProfileExporter) Shutdown(context.Context) error {
+	e.stopped.Store(true)
+	return nil
+}
@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	"go.opentelemetry.io/otel/trace"
+	collectorprofilespb "go.opentelemetry.io/proto/slim/otlp/collector/profiles/v1development"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	profilespb "go.opentelemetry.io/proto/slim/otlp/profiles/v1development"
+	"google.golang.org/protobuf/proto"
+)
+
+type recordingProfileExporter struct {
+	mu       sync.Mutex
+	exported []*profilespb.ProfilesData
+	shutdown bool
+}
+
+func (e *recordingProfileExporter) Export(_ context.Context, data *profilespb.ProfilesData) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.exported = append(e.exported, data)
+	return nil
+}
+
+func (e *recordingProfileExporter) Shutdown(context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.shutdown = true
+	return nil
+}
+
+func (e *recordingProfileExporter) exports() int {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return len(e.exported)
+}
+
+func TestNewProfileConfig(t *testing.T) {
+	c := newProfileConfig()
+	assert.Equal(t, DefaultProfileInterval, c.Interval)
+	assert.Equal(t, DefaultCPUProfileDuration, c.CPUProfileDuration)
+	assert.Equal(t, []ProfileType{ProfileCPU, ProfileHeap, ProfileGoroutine, ProfileMutex}, c.Types)
+	assert.Equal(t, resource.Default(), c.Resource)
+
+	c = newProfileConfig(
+		WithProfileInterval(time.Second),
+		WithCPUProfileDuration(time.Minute),
+		WithProfileTypes(ProfileHeap),
+		WithProfileInterval(-1),
+	)
+	assert.Equal(t, time.Second, c.Interval)
+	assert.Equal(t, time.Second, c.CPUProfileDuration, "CPU profile duration should be limited to the interval")
+	assert.Equal(t, []ProfileType{ProfileHeap}, c.Types)
+}
+
+func TestProfileProducerCollect(t *testing.T) {
+	res := resource.NewSchemaless(attribute.String("service.name", "test"))
+	exp := &recordingProfileExporter{}
+	p := NewProfileProducer(exp,
+		WithProfileInterval(time.Hour),
+		WithProfileTypes(ProfileHeap, ProfileGoroutine),
+		WithProfileResource(res),
+	)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(t.Context(), sc)
+	started, release := make(chan struct{}), make(chan struct{})
+	go DoWithSpanLabels(ctx, func(context.Context) {
+		close(started)
+		<-release
+	})
+	<-started
+	defer close(release)
+
+	data, err := p.Collect(t.Context())
+	require.NoError(t, err)
+	require.Len(t, data.ResourceProfiles, 1)
+	rp := data.ResourceProfiles[0]
+	require.Len(t, rp.Resource.Attributes, 1)
+	assertProtoEqual(t, &commonpb.KeyValue{Key: "service.name", Value: stringValue("test")}, rp.Resource.Attributes[0])
+	require.Len(t, rp.ScopeProfiles, 1)
+	assertProtoEqual(t, &commonpb.InstrumentationScope{Name: ScopeName, Version: Version}, rp.ScopeProfiles[0].Scope)
+
+	dict := data.Dictionary
+	var sampleTypes []string
+	var linked bool
+	for _, prof := range rp.ScopeProfiles[0].Profiles {
+		sampleTypes = append(sampleTypes, dict.StringTable[prof.SampleType.TypeStrindex])
+		for _, s := range prof.Samples {
+			if s.LinkIndex != 0 {
+				traceID, spanID := sc.TraceID(), sc.SpanID()
+				assertProtoEqual(t, &profilespb.Link{TraceId: traceID[:], SpanId: spanID[:]}, dict.LinkTable[s.LinkIndex])
+				linked = true
+			}
+		}
+	}
+	// The heap profile has four sample types, the goroutine profile one.
+	assert.Equal(t, []string{"alloc_objects", "alloc_space", "inuse_objects", "inuse_space", "goroutine"}, sampleTypes)
+	assert.True(t, linked, "goroutine sample should be linked to the span")
+
+	require.NoError(t, p.Shutdown(t.Context()))
+	assert.True(t, exp.shutdown)
+	assert.Error(t, p.Shutdown(t.Context()))
+}
+
+func TestProfileProducerCPU(t *testing.T) {
+	p := NewProfileProducer(&recordingProfileExporter{},
+		WithProfileInterval(time.Hour),
+		WithCPUProfileDuration(50*time.Millisecond),
+		WithProfileTypes(ProfileCPU),
+	)
+	defer func() { assert.NoError(t, p.Shutdown(t.Context())) }()
+
+	data, err := p.Collect(t.Context())
+	require.NoError(t, err)
+	profiles := data.ResourceProfiles[0].ScopeProfiles[0].Profiles
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "cpu", data.Dictionary.StringTable[profiles[1].SampleType.TypeStrindex])
+	assert.Equal(t, "nanoseconds", data.Dictionary.StringTable[profiles[1].SampleType.UnitStrindex])
+	assert.Positive(t, profiles[1].Period)
+	assert.Positive(t, profiles[1].DurationNano)
+}
+
+func TestProfileProducerExports(t *testing.T) {
+	exp := &recordingProfileExporter{}
+	p := NewProfileProducer(exp,
+		WithProfileInterval(10*time.Millisecond),
+		WithProfileTypes(ProfileGoroutine),
+	)
+	assert.Eventually(t, func() bool { return exp.exports() >= 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, p.Shutdown(t.Context()))
+}
+
+// blockingProfileExporter blocks the exports until release is closed.
+type blockingProfileExporter struct {
+	recordingProfileExporter
+
+	exporting chan struct{}
+	release   chan struct{}
+}
+
+func (e *blockingProfileExporter) Export(ctx context.Context, data *profilespb.ProfilesData) error {
+	select {
+	case e.exporting <- struct{}{}:
+	default:
+	}
+	<-e.release
+	return e.recordingProfileExporter.Export(ctx, data)
+}
+
+func TestProfileProducerShutdownContextDone(t *testing.T) {
+	exp := &blockingProfileExporter{
+		exporting: make(chan struct{}),
+		release:   make(chan struct{}),
+	}
+	defer close(exp.release)
+	p := NewProfileProducer(exp,
+		WithProfileInterval(10*time.Millisecond),
+		WithProfileTypes(ProfileGoroutine),
+	)
+	<-exp.exporting
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	assert.ErrorIs(t, p.Shutdown(ctx), context.Canceled)
+	assert.True(t, exp.shutdown, "exporter should be shut down")
+	assert.Error(t, p.Shutdown(t.Context()))
+}
+
+func TestProfileProducerMutexProfileFraction(t *testing.T) {
+	prev := runtime.SetMutexProfileFraction(2)
+	defer runtime.SetMutexProfileFraction(prev)
+
+	p := NewProfileProducer(&recordingProfileExporter{},
+		WithProfileInterval(time.Hour),
+		WithMutexProfileFraction(5),
+	)
+	// A negative rate only reads the current fraction.
+	assert.Equal(t, 5, runtime.SetMutexProfileFraction(-1))
+	require.NoError(t, p.Shutdown(t.Context()))
+	assert.Equal(t, 2, runtime.SetMutexProfileFraction(-1), "previous fraction should be restored")
+}
+
+func TestConvertProfile(t *testing.T) {
+	fn := &profile.Function{ID: 1, Name: "main.work", Filename: "main.go"}
+	loc := &profile.Location{ID: 1, Address: 0x10, Line: []profile.Line{{Function: fn, Line: 42}}}
+	p := &profile.Profile{
+		SampleType: []*profile.ValueType{{Type: "samples", Unit: "count"}},
+		Sample: []*profile.Sample{
+			{
+				Location: []*profile.Location{loc},
+				Value:    []int64{3},
+				Label: map[string][]string{
+					"handler":    {"users"},
+					traceIDLabel: {"01000000000000000000000000000000"},
+					spanIDLabel:  {"0200000000000000"},
+				},
+			},
+			{Location: []*profile.Location{loc}, Value: []int64{0}},
+			{Location: []*profile.Location{loc}, Value: []int64{1}},
+		},
+		Location:  []*profile.Location{loc},
+		Function:  []*profile.Function{fn},
+		TimeNanos: 1000,
+	}
+
+	b := newDictionaryBuilder()
+	profiles := b.convertProfile(p, func() []byte { return []byte{1} })
+	require.Len(t, profiles, 1)
+	prof := profiles[0]
+	assert.Equal(t, uint64(1000), prof.TimeUnixNano)
+	assert.Equal(t, []byte{1}, prof.ProfileId)
+	require.Len(t, prof.Samples, 2, "samples with a zero value should be dropped")
+
+	// Both samples share the stack.
+	assert.Equal(t, prof.Samples[0].StackIndex, prof.Samples[1].StackIndex)
+	stack := b.dict.StackTable[prof.Samples[0].StackIndex]
+	require.Len(t, stack.LocationIndices, 1)
+	location := b.dict.LocationTable[stack.LocationIndices[0]]
+	assert.Equal(t, uint64(0x10), location.Address)
+	require.Len(t, location.Lines, 1)
+	assert.Equal(t, int64(42), location.Lines[0].Line)
+	assert.Equal(t, "main.work", b.dict.StringTable[b.dict.FunctionTable[location.Lines[0].FunctionIndex].NameStrindex])
+
+	assertProtoEqual(t, &profilespb.Link{
+		TraceId: []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+		SpanId:  []byte{2, 0, 0, 0, 0, 0, 0, 0},
+	}, b.dict.LinkTable[prof.Samples[0].LinkIndex])
+	assert.Zero(t, prof.Samples[1].LinkIndex)
+	require.Len(t, prof.Samples[0].AttributeIndices, 1)
+	attr := b.dict.AttributeTable[prof.Samples[0].AttributeIndices[0]]
+	assert.Equal(t, "handler", b.dict.StringTable[attr.KeyStrindex])
+	assertProtoEqual(t, stringValue("users"), attr.Value)
+}
+
+func TestKeyValues(t *testing.T) {
+	set := attribute.NewSet(
+		attribute.Bool("bool", true),
+		attribute.Float64("double", 1.5),
+		attribute.Int64("int", 42),
+		attribute.StringSlice("slice", []string{"a"}),
+		attribute.String("string", "v"),
+	)
+	got := keyValues(&set)
+	want := []*commonpb.KeyValue{
+		{Key: "bool", Value: boolValue(true)},
+		{Key: "double", Value: doubleValue(1.5)},
+		{Key: "int", Value: intValue(42)},
+		{Key: "slice", Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{
+			ArrayValue: &commonpb.ArrayValue{Values: []*commonpb.AnyValue{stringValue("a")}},
+		}}},
+		{Key: "string", Value: stringValue("v")},
+	}
+	require.Len(t, got, len(want))
+	for i := range want {
+		assertProtoEqual(t, want[i], got[i])
+	}
+
+	assert.Nil(t, keyValues(nil))
+}
+
+func TestFileProfileExporter(t *testing.T) {
+	var buf bytes.Buffer
+	exp := NewFileProfileExporter(&buf)
+	data := testProfilesData()
+	require.NoError(t, exp.Export(t.Context(), data))
+	require.NoError(t, exp.Export(t.Context(), data))
+	require.NoError(t, exp.Shutdown(t.Context()))
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	require.Len(t, lines, 2)
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(lines[0], &got))
+	prof := got["resourceProfiles"].([]any)[0].(map[string]any)["scopeProfiles"].([]any)[0].(map[string]any)["profiles"].([]any)[0].(map[string]any)
+	assert.Equal(t, "01000000000000000000000000000000", prof["profileId"])
+	assert.Equal(t, "1", prof["timeUnixNano"])
+	link := got["dictionary"].(map[string]any)["linkTable"].([]any)[1].(map[string]any)
+	assert.Equal(t, "01000000000000000000000000000000", link["traceId"])
+	assert.Equal(t, "0200000000000000", link["spanId"])
+}
+
+func TestOTLPProfileExporter(t *testing.T) {
+	var got collectorprofilespb.ExportProfilesServiceRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, proto.Unmarshal(body, &got))
+		resp, err := proto.Marshal(&collectorprofilespb.ExportProfilesServiceResponse{})
+		assert.NoError(t, err)
+		_, _ = w.Write(resp)
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPProfileExporter(srv.URL+"/v1development/profiles", nil)
+	data := testProfilesData()
+	require.NoError(t, exp.Export(t.Context(), data))
+	assertProtoEqual(t, &collectorprofilespb.ExportProfilesServiceRequest{
+		ResourceProfiles: data.ResourceProfiles,
+		Dictionary:       data.Dictionary,
+	}, &got)
+
+	require.NoError(t, exp.Shutdown(t.Context()))
+	assert.Error(t, exp.Export(t.Context(), data), "export after shutdown")
+}
+
+func TestOTLPProfileExporterError(t *testing.T) {
+	partial := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if !partial {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := proto.Marshal(&collectorprofilespb.ExportProfilesServiceResponse{
+			PartialSuccess: &collectorprofilespb.ExportProfilesPartialSuccess{RejectedProfiles: 1, ErrorMessage: "invalid"},
+		})
+		assert.NoError(t, err)
+		_, _ = w.Write(resp)
+	}))
+	defer srv.Close()
+
+	exp := NewOTLPProfileExporter(srv.URL, srv.Client())
+	assert.ErrorContains(t, exp.Export(t.Context(), testProfilesData()), "400 Bad Request")
+	partial = true
+	assert.ErrorContains(t, exp.Export(t.Context(), testProfilesData()), "1 profiles rejected: invalid")
+}
+
+func testProfilesData() *profilespb.ProfilesData {
+	return &profilespb.ProfilesData{
+		ResourceProfiles: []*profilespb.ResourceProfiles{{
+			ScopeProfiles: []*profilespb.ScopeProfiles{{
+				Profiles: []*profilespb.Profile{{
+					ProfileId:    []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+					TimeUnixNano: 1,
+					Samples:      []*profilespb.Sample{{LinkIndex: 1}},
+				}},
+			}},
+		}},
+		Dictionary: &profilespb.ProfilesDictionary{
+			LinkTable: []*profilespb.Link{{}, {
+				TraceId: []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0},
+				SpanId:  []byte{2, 0, 0, 0, 0, 0, 0, 0},
+			}},
+		},
+	}
+}
+
+func assertProtoEqual(t *testing.T, want, got proto.Message) {
+	t.Helper()
+	assert.True(t, proto.Equal(want, got), "want %v, got %v", want, got)
+}
@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package runtime
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/pprof/profile"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/slim/otlp/common/v1"
+	profilespb "go.opentelemetry.io/proto/slim/otlp/profiles/v1development"
+)
+
+// dictionaryBuilder builds the dictionary shared by the profiles of a
+// ProfilesData, deduplicating its entries.
+type dictionaryBuilder struct {
+	dict *profilespb.ProfilesDictionary
+
+	strings   map[string]int32
+	functions map[functionKey]int32
+	mappings  map[mappingKey]int32
+	stacks    map[string]int32
+	links     map[linkKey]int32
+	attrs     map[[2]int32]int32
+}
+
+type functionKey struct {
+	name, systemName, filename int32
+	startLine                  int64
+}
+
+type mappingKey struct {
+	start, limit, offset uint64
+	filename             int32
+}
+
+type linkKey struct {
+	traceID trace.TraceID
+	spanID  trace.SpanID
+}
+
+func newDictionaryBuilder() *dictionaryBuilder {
+	return &dictionaryBuilder{
+		// The first entry of every table is the zero value.
+		dict: &profilespb.ProfilesDictionary{
+			MappingTable:   []*profilespb.Mapping{{}},
+			LocationTable:  []*profilespb.Location{{}},
+			FunctionTable:  []*profilespb.Function{{}},
+			LinkTable:      []*profilespb.Link{{}},
+			StringTable:    []string{""},
+			AttributeTable: []*profilespb.KeyValueAndUnit{{}},
+			StackTable:     []*profilespb.Stack{{}},
+		},
+		strings:   map[string]int32{"": 0},
+		functions: map[functionKey]int32{{}: 0},
+		mappings:  map[mappingKey]int32{{}: 0},
+		stacks:    map[string]int32{"": 0},
+		links:     map[linkKey]int32{{}: 0},
+		attrs:     map[[2]int32]int32{},
+	}
+}
+
+func (b *dictionaryBuilder) str(s string) int32 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int32(len(b.dict.StringTable))
+	b.dict.StringTable = append(b.dict.StringTable, s)
+	b.strings[s] = i
+	return i
+}
+
+func (b *dictionaryBuilder) function(f *profile.Function) int32 {
+	key := functionKey{
+		name:       b.str(f.Name),
+		systemName: b.str(f.SystemName),
+		filename:   b.str(f.Filename),
+		startLine:  f.StartLine,
+	}
+	if i, ok := b.functions[key]; ok {
+		return i
+	}
+	i := int32(len(b.dict.FunctionTable))
+	b.dict.FunctionTable = append(b.dict.FunctionTable, &profilespb.Function{
+		NameStrindex:       key.name,
+		SystemNameStrindex: key.systemName,
+		FilenameStrindex:   key.filename,
+		StartLine:          key.startLine,
+	})
+	b.functions[key] = i
+	return i
+}
+
+func (b *dictionaryBuilder) mapping(m *profile.Mapping) int32 {
+	if m == nil {
+		return 0
+	}
+	key := mappingKey{start: m.Start, limit: m.Limit, offset: m.Offset, filename: b.str(m.File)}
+	if i, ok := b.mappings[key]; ok {
+		return i
+	}
+	i := int32(len(b.dict.MappingTable))
+	b.dict.MappingTable = append(b.dict.MappingTable, &profilespb.Mapping{
+		MemoryStart:      key.start,
+		MemoryLimit:      key.limit,
+		FileOffset:       key.offset,
+		FilenameStrindex: key.filename,
+	})
+	b.mappings[key] = i
+	return i
+}
+
+func (b *dictionaryBuilder) stack(locations []int32) int32 {
+	var key strings.Builder
+	for _, l := range locations {
+		key.WriteString(strconv.Itoa(int(l)))
+		key.WriteByte(',')
+	}
+	if i, ok := b.stacks[key.String()]; ok {
+		return i
+	}
+	i := int32(len(b.dict.StackTable))
+	b.dict.StackTable = append(b.dict.StackTable, &profilespb.Stack{LocationIndices: locations})
+	b.stacks[key.String()] = i
+	return i
+}
+
+func (b *dictionaryBuilder) link(traceID trace.TraceID, spanID trace.SpanID) int32 {
+	key := linkKey{traceID: traceID, spanID: spanID}
+	if i, ok := b.links[key]; ok {
+		return i
+	}
+	i := int32(len(b.dict.LinkTable))
+	b.dict.LinkTable = append(b.dict.LinkTable, &profilespb.Link{
+		TraceId: traceID[:],
+		SpanId:  spanID[:],
+	})
+	b.links[key] = i
+	return i
+}
+
+func (b *dictionaryBuilder) attr(key, value string) int32 {
+	k := [2]int32{b.str(key), b.str(value)}
+	if i, ok := b.attrs[k]; ok {
+		return i
+	}
+	i := int32(len(b.dict.AttributeTable))
+	b.dict.AttributeTable = append(b.dict.AttributeTable, &profilespb.KeyValueAndUnit{
+		KeyStrindex: k[0],
+		Value:       stringValue(value),
+	})
+	b.attrs[k] = i
+	return i
+}
+
+// convertProfile converts a pprof profile to one profile per sample type.
+//
+// The string labels of the samples are converted to attributes, except the
+// trace ID and span ID labels set by [DoWithSpanLabels] that are converted
+// to a link.
+func (b *dictionaryBuilder) convertProfile(p *profile.Profile, id func() []byte) []*profilespb.Profile {
+	// The IDs of the locations are only unique within a pprof profile.
+	locations := make(map[uint64]int32, len(p.Location))
+	for _, l := range p.Location {
+		loc := &profilespb.Location{
+			MappingIndex: b.mapping(l.Mapping),
+			Address:      l.Address,
+		}
+		for _, line := range l.Line {
+			ln := &profilespb.Line{Line: line.Line, Column: line.Column}
+			if line.Function != nil {
+				ln.FunctionIndex = b.function(line.Function)
+			}
+			loc.Lines = append(loc.Lines, ln)
+		}
+		locations[l.ID] = int32(len(b.dict.LocationTable))
+		b.dict.LocationTable = append(b.dict.LocationTable, loc)
+	}
+
+	type sampleRef struct {
+		stack, link int32
+		attrs       []int32
+	}
+	refs := make([]sampleRef, len(p.Sample))
+	for i, s := range p.Sample {
+		locs := make([]int32, 0, len(s.Location))
+		for _, l := range s.Location {
+			locs = append(locs, locations[l.ID])
+		}
+		refs[i].stack = b.stack(locs)
+		refs[i].link = b.sampleLink(s.Label)
+		for _, k := range slices.Sorted(maps.Keys(s.Label)) {
+			if k == traceIDLabel || k == spanIDLabel {
+				continue
+			}
+			for _, v := range s.Label[k] {
+				refs[i].attrs = append(refs[i].attrs, b.attr(k, v))
+			}
+		}
+	}
+
+	var periodType *profilespb.ValueType
+	if p.PeriodType != nil {
+		periodType = &profilespb.ValueType{
+			TypeStrindex: b.str(p.PeriodType.Type),
+			UnitStrindex: b.str(p.PeriodType.Unit),
+		}
+	}
+
+	out := make([]*profilespb.Profile, 0, len(p.SampleType))
+	for t, st := range p.SampleType {
+		prof := &profilespb.Profile{
+			SampleType: &profilespb.ValueType{
+				TypeStrindex: b.str(st.Type),
+				UnitStrindex: b.str(st.Unit),
+			},
+			TimeUnixNano: uint64(max(p.TimeNanos, 0)),
+			DurationNano: uint64(max(p.DurationNanos, 0)),
+			PeriodType:   periodType,
+			Period:       p.Period,
+			ProfileId:    id(),
+		}
+		for i, s := range p.Sample {
+			if s.Value[t] == 0 {
+				continue
+			}
+			prof.Samples = append(prof.Samples, &profilespb.Sample{
+				StackIndex:       refs[i].stack,
+				Values:           []int64{s.Value[t]},
+				AttributeIndices: refs[i].attrs,
+				LinkIndex:        refs[i].link,
+			})
+		}
+		out = append(out, prof)
+	}
+	return out
+}
+
+// sampleLink returns the index of the link to the span identified by the
+// labels of a sample, or 0 if the labels do not identify a span.
+func (b *dictionaryBuilder) sampleLink(labels map[string][]string) int32 {
+	if len(labels[traceIDLabel]) != 1 || len(labels[spanIDLabel]) != 1 {
+		return 0
+	}
+	traceID, err := trace.TraceIDFromHex(labels[traceIDLabel][0])
+	if err != nil {
+		return 0
+	}
+	spanID, err := trace.SpanIDFromHex(labels[spanIDLabel][0])
+	if err != nil {
+		return 0
+	}
+	return b.link(traceID, spanID)
+}
+
+// keyValues returns the OTLP key-value pairs of the attributes of set.
+func keyValues(set *attribute.Set) []*commonpb.KeyValue {
+	if set == nil || set.Len() == 0 {
+		return nil
+	}
+	out := make([]*commonpb.KeyValue, 0, set.Len())
+	for _, kv := range set.ToSlice() {
+		out = append(out, &commonpb.KeyValue{Key: string(kv.Key), Value: anyValue(kv.Value)})
+	}
+	return out
+}
+
+func anyValue(v attribute.Value) *commonpb.AnyValue {
+	switch v.Type() {
+	case attribute.BOOL:
+		return boolValue(v.AsBool())
+	case attribute.INT64:
+		return intValue(v.AsInt64())
+	case attribute.FLOAT64:
+		return doubleValue(v.AsFloat64())
+	case attribute.STRING:
+		return stringValue(v.AsString())
+	case attribute.BOOLSLICE:
+		return arrayValue(v.AsBoolSlice(), boolValue)
+	case attribute.INT64SLICE:
+		return arrayValue(v.AsInt64Slice(), intValue)
+	case attribute.FLOAT64SLICE:
+		return arrayValue(v.AsFloat64Slice(), doubleValue)
+	case attribute.STRINGSLICE:
+		return arrayValue(v.AsStringSlice(), stringValue)
+	}
+	return stringValue(v.Emit())
+}
+
+func stringValue(v string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: v}}
+}
+
+func boolValue(v bool) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: v}}
+}
+
+func intValue(v int64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: v}}
+}
+
+func doubleValue(v float64) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: v}}
+}
+
+func arrayValue[T any](vals []T, conv func(T) *commonpb.AnyValue) *commonpb.AnyValue {
+	arr := &commonpb.ArrayValue{Values: make([]*commonpb.AnyValue, 0, len(vals))}
+	for _, v := range vals {
+		arr.Values = append(arr.Values, conv(v))
+	}
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: arr}}
+}
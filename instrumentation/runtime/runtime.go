@@ -7,6 +7,7 @@ import (
 	"context"
 	"math"
 	"runtime/metrics"
+	"slices"
 	"sync"
 	"time"
 
@@ -33,6 +34,15 @@ const (
 	goMaxProcs          = "/sched/gomaxprocs:threads"
 	goConfigGC          = "/gc/gogc:percent"
 	goSchedLatencies    = "/sched/latencies:seconds"
+
+	goGCCycles           = "/gc/cycles/total:gc-cycles"
+	goHeapObjects        = "/gc/heap/objects:objects"
+	goGCPauses           = "/sched/pauses/total/gc:seconds"
+	goHeapAllocsBySize   = "/gc/heap/allocs-by-size:bytes"
+	goMutexWait          = "/sync/mutex/wait/total:seconds"
+	goCgoCalls           = "/cgo/go-to-c-calls:calls"
+	goFinalizersQueued   = "/gc/finalizers/queued:finalizers"
+	goFinalizersExecuted = "/gc/finalizers/executed:finalizers"
 )
 
 // Start initializes reporting of runtime metrics using the supplied config.
@@ -66,6 +76,9 @@ const (
 //	runtime.go.mem.heap_sys      (bytes)    Bytes of heap memory obtained from the OS
 //	runtime.go.mem.live_objects  -          Number of live objects is the number of cumulative Mallocs - Frees
 //	runtime.uptime               (ms)       Milliseconds since application was initialized
+//
+// Additional metrics are emitted when enabled with [WithGCMetrics],
+// [WithMutexMetrics], [WithCgoMetrics] and [WithFinalizerMetrics].
 func Start(opts ...Option) error {
 	c := newConfig(opts...)
 	meter := c.MeterProvider.Meter(
@@ -110,13 +123,31 @@ func Start(opts ...Option) error {
 		return err
 	}
 
+	instruments := []metric.Observable{
+		memoryUsed.Inst(),
+		memoryLimit.Inst(),
+		memoryAllocated.Inst(),
+		memoryAllocations.Inst(),
+		memoryGCGoal.Inst(),
+		goroutineCount.Inst(),
+		processorLimit.Inst(),
+		configGogc.Inst(),
+	}
+	metricNames := slices.Clone(runtimeMetrics)
+	opt, err := newOptionalInstruments(meter, c)
+	if err != nil {
+		return err
+	}
+	instruments = append(instruments, opt.instruments()...)
+	metricNames = append(metricNames, opt.metricNames()...)
+
 	otherMemoryOpt := metric.WithAttributeSet(
 		attribute.NewSet(memoryUsed.AttrMemoryType(goconv.MemoryTypeOther)),
 	)
 	stackMemoryOpt := metric.WithAttributeSet(
 		attribute.NewSet(memoryUsed.AttrMemoryType(goconv.MemoryTypeStack)),
 	)
-	collector := newCollector(c.MinimumReadMemStatsInterval, runtimeMetrics)
+	collector := newCollector(c.MinimumReadMemStatsInterval, metricNames)
 	var lock sync.Mutex
 	_, err = meter.RegisterCallback(
 		func(_ context.Context, o metric.Observer) error {
@@ -138,16 +169,10 @@ func Start(opts ...Option) error {
 			o.ObserveInt64(goroutineCount.Inst(), collector.getInt(goGoroutines))
 			o.ObserveInt64(processorLimit.Inst(), collector.getInt(goMaxProcs))
 			o.ObserveInt64(configGogc.Inst(), collector.getInt(goConfigGC))
+			opt.observe(o, collector)
 			return nil
 		},
-		memoryUsed.Inst(),
-		memoryLimit.Inst(),
-		memoryAllocated.Inst(),
-		memoryAllocations.Inst(),
-		memoryGCGoal.Inst(),
-		goroutineCount.Inst(),
-		processorLimit.Inst(),
-		configGogc.Inst(),
+		instruments...,
 	)
 	if err != nil {
 		return err
@@ -169,6 +194,123 @@ var runtimeMetrics = []string{
 	goConfigGC,
 }
 
+// optionalInstruments holds the instruments of the metrics enabled by
+// options. Instruments of disabled metrics are nil.
+type optionalInstruments struct {
+	gcCycles       metric.Int64ObservableCounter
+	heapObjects    metric.Int64ObservableUpDownCounter
+	mutexWait      metric.Float64ObservableCounter
+	cgoCalls       metric.Int64ObservableCounter
+	finalizerQueue metric.Int64ObservableUpDownCounter
+}
+
+func newOptionalInstruments(meter metric.Meter, c config) (optionalInstruments, error) {
+	var (
+		inst optionalInstruments
+		err  error
+	)
+	if c.GCMetrics {
+		var gcCycles goconv.MemoryGCCyclesObservable
+		gcCycles, err = goconv.NewMemoryGCCyclesObservable(meter)
+		if err != nil {
+			return inst, err
+		}
+		inst.gcCycles = gcCycles.Inst()
+		inst.heapObjects, err = meter.Int64ObservableUpDownCounter(
+			"memory.heap.objects",
+			metric.WithDescription("Count of live and unswept objects occupying heap memory."),
+			metric.WithUnit("{object}"),
+		)
+		if err != nil {
+			return inst, err
+		}
+	}
+	if c.MutexMetrics {
+		inst.mutexWait, err = meter.Float64ObservableCounter(
+			"sync.mutex.wait",
+			metric.WithDescription("Approximate cumulative time goroutines have spent blocked on a sync.Mutex, sync.RWMutex, or runtime-internal lock."),
+			metric.WithUnit("s"),
+		)
+		if err != nil {
+			return inst, err
+		}
+	}
+	if c.CgoMetrics {
+		inst.cgoCalls, err = meter.Int64ObservableCounter(
+			"cgo.calls",
+			metric.WithDescription("Count of calls made from Go to C by the current process."),
+			metric.WithUnit("{call}"),
+		)
+		if err != nil {
+			return inst, err
+		}
+	}
+	if c.FinalizerMetrics {
+		inst.finalizerQueue, err = meter.Int64ObservableUpDownCounter(
+			"finalizer.queue.size",
+			metric.WithDescription("Count of finalizers queued for execution but not yet executed."),
+			metric.WithUnit("{finalizer}"),
+		)
+		if err != nil {
+			return inst, err
+		}
+	}
+	return inst, nil
+}
+
+func (inst optionalInstruments) instruments() []metric.Observable {
+	var obs []metric.Observable
+	if inst.gcCycles != nil {
+		obs = append(obs, inst.gcCycles, inst.heapObjects)
+	}
+	if inst.mutexWait != nil {
+		obs = append(obs, inst.mutexWait)
+	}
+	if inst.cgoCalls != nil {
+		obs = append(obs, inst.cgoCalls)
+	}
+	if inst.finalizerQueue != nil {
+		obs = append(obs, inst.finalizerQueue)
+	}
+	return obs
+}
+
+// metricNames returns the runtime metrics read to observe the instruments.
+func (inst optionalInstruments) metricNames() []string {
+	var names []string
+	if inst.gcCycles != nil {
+		names = append(names, goGCCycles, goHeapObjects)
+	}
+	if inst.mutexWait != nil {
+		names = append(names, goMutexWait)
+	}
+	if inst.cgoCalls != nil {
+		names = append(names, goCgoCalls)
+	}
+	if inst.finalizerQueue != nil {
+		names = append(names, goFinalizersQueued, goFinalizersExecuted)
+	}
+	return names
+}
+
+func (inst optionalInstruments) observe(o metric.Observer, collector *goCollector) {
+	if inst.gcCycles != nil {
+		o.ObserveInt64(inst.gcCycles, collector.getInt(goGCCycles))
+		o.ObserveInt64(inst.heapObjects, collector.getInt(goHeapObjects))
+	}
+	if inst.mutexWait != nil {
+		o.ObserveFloat64(inst.mutexWait, collector.getFloat(goMutexWait))
+	}
+	if inst.cgoCalls != nil {
+		o.ObserveInt64(inst.cgoCalls, collector.getInt(goCgoCalls))
+	}
+	// The finalizer metrics are not supported by all Go versions.
+	if inst.finalizerQueue != nil && collector.supported(goFinalizersQueued) {
+		queued := collector.getInt(goFinalizersQueued) - collector.getInt(goFinalizersExecuted)
+		o.ObserveInt64(inst.finalizerQueue, max(queued, 0))
+	}
+}
+
 type goCollector struct {
 	// now is used to replace the implementation of time.Now for testing
 	now func() time.Time
@@ -221,6 +363,20 @@ func (g *goCollector) getInt(name string) int64 {
 	return 0
 }
 
+func (g *goCollector) getFloat(name string) float64 {
+	if s, ok := g.sampleMap[name]; ok && s.Value.Kind() == metrics.KindFloat64 {
+		return s.Value.Float64()
+	}
+	return 0
+}
+
+// supported returns whether the runtime provided a value for name at the last
+// refresh.
+func (g *goCollector) supported(name string) bool {
+	s, ok := g.sampleMap[name]
+	return ok && s.Value.Kind() != metrics.KindBad
+}
+
 func (g *goCollector) getHistogram(name string) *metrics.Float64Histogram {
 	if s, ok := g.sampleMap[name]; ok && s.Value.Kind() == metrics.KindFloat64Histogram {
 		return s.Value.Float64Histogram()
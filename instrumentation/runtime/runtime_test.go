@@ -5,6 +5,7 @@ package runtime
 
 import (
 	"math"
+	"runtime"
 	"runtime/debug"
 	"testing"
 	"time"
@@ -178,6 +179,57 @@ func TestRuntimeWithLimit(t *testing.T) {
 	assertNonZeroValues(t, rm.ScopeMetrics[0])
 }
 
+func TestRuntimeWithOptionalMetrics(t *testing.T) {
+	runtime.GC()
+	runtime.SetFinalizer(new([16]byte), func(*[16]byte) {})
+
+	reader := metric.NewManualReader()
+	mp := metric.NewMeterProvider(metric.WithReader(reader))
+	err := Start(
+		WithMeterProvider(mp),
+		WithGCMetrics(),
+		WithMutexMetrics(),
+		WithCgoMetrics(),
+		WithFinalizerMetrics(),
+	)
+	require.NoError(t, err)
+	rm := metricdata.ResourceMetrics{}
+	require.NoError(t, reader.Collect(t.Context(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	got := make(map[string]metricdata.Metrics)
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		got[m.Name] = m
+	}
+
+	gcCycles, ok := got[goconv.MemoryGCCycles{}.Name()].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.True(t, gcCycles.IsMonotonic)
+	require.Len(t, gcCycles.DataPoints, 1)
+	assert.Positive(t, gcCycles.DataPoints[0].Value)
+
+	heapObjects, ok := got["memory.heap.objects"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, heapObjects.DataPoints, 1)
+	assert.Positive(t, heapObjects.DataPoints[0].Value)
+
+	mutexWait, ok := got["sync.mutex.wait"].Data.(metricdata.Sum[float64])
+	require.True(t, ok)
+	assert.True(t, mutexWait.IsMonotonic)
+	require.Len(t, mutexWait.DataPoints, 1)
+
+	cgoCalls, ok := got["cgo.calls"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.True(t, cgoCalls.IsMonotonic)
+	require.Len(t, cgoCalls.DataPoints, 1)
+
+	finalizerQueue, ok := got["finalizer.queue.size"].Data.(metricdata.Sum[int64])
+	require.True(t, ok)
+	assert.False(t, finalizerQueue.IsMonotonic)
+	require.Len(t, finalizerQueue.DataPoints, 1)
+	assert.GreaterOrEqual(t, finalizerQueue.DataPoints[0].Value, int64(0))
+}
+
 func assertNonZeroValues(t *testing.T, sm metricdata.ScopeMetrics) {
 	for _, m := range sm.Metrics {
 		switch a := m.Data.(type) {
@@ -75,6 +75,8 @@ require (
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
 	go.opentelemetry.io/contrib/propagators/aws v1.45.0 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.45.0 // indirect
+	go.opentelemetry.io/contrib/propagators/cloudtrace v0.70.0 // indirect
+	go.opentelemetry.io/contrib/propagators/datadog v0.70.0 // indirect
 	go.opentelemetry.io/contrib/propagators/jaeger v1.45.0 // indirect
 	go.opentelemetry.io/contrib/propagators/ot v1.45.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlptrace v1.45.0 // indirect
@@ -121,3 +123,7 @@ replace go.opentelemetry.io/contrib/detectors/aws/eks => ../detectors/aws/eks
 replace go.opentelemetry.io/contrib/detectors/azure/azurevm => ../detectors/azure/azurevm
 
 replace go.opentelemetry.io/contrib/detectors/gcp => ../detectors/gcp
+
+replace go.opentelemetry.io/contrib/propagators/datadog => ../propagators/datadog
+
+replace go.opentelemetry.io/contrib/propagators/cloudtrace => ../propagators/cloudtrace
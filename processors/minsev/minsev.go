@@ -7,7 +7,9 @@ package minsev
 
 import (
 	"context"
+	"sync"
 
+	api "go.opentelemetry.io/otel/log"
 	"go.opentelemetry.io/otel/sdk/log"
 )
 
@@ -20,22 +22,63 @@ import (
 // for each record processed or queried; to adjust the minimum level
 // dynamically, use a [SeverityVar].
 //
+// The minimum severity of the records of some instrumentation scopes or with
+// some attributes can be set with [WithRules]. severity applies to the other
+// records.
+//
 // If downstream is nil a default No-Op [log.Processor] is used. The returned
 // processor will not be enabled for nor emit any records.
-func NewLogProcessor(downstream log.Processor, severity Severitier) *LogProcessor {
+func NewLogProcessor(downstream log.Processor, severity Severitier, opts ...Option) *LogProcessor {
 	if downstream == nil {
 		downstream = defaultProcessor
 	}
 	if severity == nil {
 		severity = SeverityInfo
 	}
+	var cfg config
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
 	return &LogProcessor{
 		Processor: downstream,
 		sev:       severity,
 		wrapped:   downstream,
+		rules:     cfg.rules,
 	}
 }
 
+type config struct {
+	rules []Rule
+}
+
+// Option configures a [LogProcessor].
+type Option interface {
+	apply(config) config
+}
+
+type optFunc func(config) config
+
+func (f optFunc) apply(c config) config { return f(c) }
+
+// WithRules returns an [Option] that configures the minimum severity of the
+// records the rules apply to. The first rule applying to a record, in the
+// order of rules, determines its minimum severity. The minimum severity of
+// the records no rule applies to is the severity passed to
+// [NewLogProcessor].
+//
+// For example, a noisy library can be limited to warnings while the other
+// records are logged from the debug severity:
+//
+//	minsev.NewLogProcessor(processor, minsev.SeverityDebug, minsev.WithRules(
+//		minsev.Rule{Scope: "github.com/noisy/lib/*", Severity: minsev.SeverityWarn},
+//	))
+func WithRules(rules ...Rule) Option {
+	return optFunc(func(c config) config {
+		c.rules = append(c.rules, rules...)
+		return c
+	})
+}
+
 // LogProcessor is an [log.Processor] implementation that wraps another
 // [log.Processor]. It will pass-through calls to OnEmit and Enabled for
 // records with severity greater than or equal to a minimum. All other method
@@ -49,6 +92,10 @@ type LogProcessor struct {
 
 	wrapped log.Processor
 	sev     Severitier
+
+	rules []Rule
+	// scopeRules caches the rules matching an instrumentation scope name.
+	scopeRules sync.Map // map[string][]Rule
 }
 
 // Compile time assertion that LogProcessor implements log.Processor and log.FilterProcessor.
@@ -58,7 +105,7 @@ var _ log.Processor = (*LogProcessor)(nil)
 // of record is greater than or equal to p.Minimum. Otherwise, record is
 // dropped.
 func (p *LogProcessor) OnEmit(ctx context.Context, record *log.Record) error {
-	if record.Severity() >= p.sev.Severity() {
+	if record.Severity() >= p.recordMinimum(record) {
 		return p.Processor.OnEmit(ctx, record)
 	}
 	return nil
@@ -67,13 +114,71 @@ func (p *LogProcessor) OnEmit(ctx context.Context, record *log.Record) error {
 // Enabled returns if the [log.Processor] that p wraps is enabled if the
 // severity of param is greater than or equal to p.Minimum. Otherwise false is
 // returned.
+//
+// If rules are configured with [WithRules], the minimum is the one of the
+// instrumentation scope of param. As the attributes of a record are not known,
+// this is the lowest minimum of the rules that may apply to a record of the
+// scope.
 func (p *LogProcessor) Enabled(ctx context.Context, param log.EnabledParameters) bool {
 	sev := param.Severity
 	if p.wrapped != nil {
-		return sev >= p.sev.Severity() &&
+		return sev >= p.scopeMinimum(param.InstrumentationScope.Name) &&
 			p.wrapped.Enabled(ctx, param)
 	}
-	return sev >= p.sev.Severity()
+	return sev >= p.scopeMinimum(param.InstrumentationScope.Name)
+}
+
+// matching returns the rules matching the instrumentation scope name.
+func (p *LogProcessor) matching(name string) []Rule {
+	if r, ok := p.scopeRules.Load(name); ok {
+		return r.([]Rule)
+	}
+	var rules []Rule
+	for _, r := range p.rules {
+		if matchScope(r.Scope, name) {
+			rules = append(rules, r)
+		}
+	}
+	p.scopeRules.Store(name, rules)
+	return rules
+}
+
+// recordMinimum returns the minimum severity of record.
+func (p *LogProcessor) recordMinimum(record *log.Record) api.Severity {
+	if len(p.rules) == 0 {
+		return p.sev.Severity()
+	}
+	for _, r := range p.matching(record.InstrumentationScope().Name) {
+		if r.matchesRecord(record) {
+			return r.severity()
+		}
+	}
+	return p.sev.Severity()
+}
+
+// scopeMinimum returns the lowest minimum severity of the records of the
+// instrumentation scope name.
+func (p *LogProcessor) scopeMinimum(name string) api.Severity {
+	if len(p.rules) == 0 {
+		return p.sev.Severity()
+	}
+	minimum := api.Severity(0)
+	found := false
+	for _, r := range p.matching(name) {
+		if sev := r.severity(); !found || sev < minimum {
+			minimum, found = sev, true
+		}
+		if r.Attribute.Key == "" {
+			// The rule applies to all the records not matching the
+			// previous rules, the following rules and the default
+			// never apply.
+			return minimum
+		}
+	}
+	if sev := p.sev.Severity(); !found || sev < minimum {
+		minimum = sev
+	}
+	return minimum
 }
 
 var defaultProcessor = noopProcessor{}
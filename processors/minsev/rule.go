@@ -0,0 +1,84 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package minsev
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// Rule is a minimum severity applying to the records of the instrumentation
+// scopes matching Scope and, if Attribute has a key, holding Attribute.
+type Rule struct {
+	// Scope is the instrumentation scope name the rule applies to. A "*"
+	// matches any sequence of characters, for example "github.com/org/*"
+	// matches the scopes of all the packages of the org module. An empty
+	// Scope matches every scope.
+	Scope string
+
+	// Attribute, if its key is not empty, restricts the rule to the records
+	// having an attribute with the same key and value, for example
+	// attribute.String("tenant", "acme").
+	Attribute attribute.KeyValue
+
+	// Severity reports the minimum record severity that will be logged for
+	// the records the rule applies to. If Severity is nil, SeverityInfo is
+	// used. To adjust the minimum level dynamically, use a [SeverityVar].
+	Severity Severitier
+}
+
+func (r Rule) severity() log.Severity {
+	if r.Severity == nil {
+		return SeverityInfo.Severity()
+	}
+	return r.Severity.Severity()
+}
+
+// matchesRecord returns if the attribute of r, if any, is held by record.
+func (r Rule) matchesRecord(record *sdklog.Record) bool {
+	if r.Attribute.Key == "" {
+		return true
+	}
+	var found bool
+	record.WalkAttributes(func(kv attribute.KeyValue) bool {
+		if kv.Key != r.Attribute.Key {
+			return true
+		}
+		found = kv.Value.Type() == r.Attribute.Value.Type() &&
+			kv.Value.Emit() == r.Attribute.Value.Emit()
+		return false
+	})
+	return found
+}
+
+// matchScope returns if the instrumentation scope name matches pattern, where
+// a "*" matches any sequence of characters.
+func matchScope(pattern, name string) bool {
+	if pattern == "" {
+		return true
+	}
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+
+	// The parts between the wildcards are matched leftmost, which is
+	// sufficient as a wildcard matches any sequence.
+	first, last := parts[0], parts[len(parts)-1]
+	if !strings.HasPrefix(name, first) {
+		return false
+	}
+	name = name[len(first):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, last)
+}
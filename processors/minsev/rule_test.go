@@ -0,0 +1,86 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package minsev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	api "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/log"
+)
+
+func TestMatchScope(t *testing.T) {
+	tests := []struct {
+		pattern, name string
+		want          bool
+	}{
+		{"", "any", true},
+		{"a/b", "a/b", true},
+		{"a/b", "a/bc", false},
+		{"*", "", true},
+		{"a/*", "a/b/c", true},
+		{"a/*", "b/a/c", false},
+		{"*/c", "a/b/c", true},
+		{"*/c", "a/b/cd", false},
+		{"a/*/c", "a/b/c", true},
+		{"a/*/c", "a/c", false},
+		{"a*b*c", "abc", true},
+		{"a*b*c", "axxbyyc", true},
+		{"a*b*c", "axxcyyb", false},
+		{"a*a", "a", false},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.want, matchScope(tt.pattern, tt.name), "matchScope(%q, %q)", tt.pattern, tt.name)
+	}
+}
+
+func TestLogProcessorRules(t *testing.T) {
+	noisy := new(SeverityVar)
+	noisy.Set(SeverityWarn)
+	tenant := attribute.String("tenant", "acme")
+
+	wrapped := new(processor)
+	p := NewLogProcessor(wrapped, SeverityInfo, WithRules(
+		Rule{Scope: "github.com/noisy/*", Attribute: tenant, Severity: SeverityDebug},
+		Rule{Scope: "github.com/noisy/*", Severity: noisy},
+		Rule{Attribute: tenant, Severity: SeverityTrace},
+	))
+
+	enabled := func(scope string, sev api.Severity) bool {
+		return p.Enabled(t.Context(), log.EnabledParameters{
+			InstrumentationScope: instrumentation.Scope{Name: scope},
+			Severity:             sev,
+		})
+	}
+	// The attributes of the records are not known, so a scope is enabled
+	// for the lowest severity of its rules.
+	assert.True(t, enabled("github.com/noisy/lib", api.SeverityDebug))
+	assert.False(t, enabled("github.com/noisy/lib", api.SeverityTrace))
+	assert.True(t, enabled("github.com/app", api.SeverityTrace))
+
+	lp := log.NewLoggerProvider(log.WithProcessor(p))
+	emitted := func(scope string, sev api.Severity, attrs ...attribute.KeyValue) bool {
+		wrapped.Reset()
+		var r api.Record
+		r.SetSeverity(sev)
+		r.AddAttributes(attrs...)
+		lp.Logger(scope).Emit(t.Context(), r)
+		return len(wrapped.OnEmitCalls) == 1
+	}
+
+	assert.False(t, emitted("github.com/noisy/lib", api.SeverityInfo))
+	assert.True(t, emitted("github.com/noisy/lib", api.SeverityWarn))
+	assert.True(t, emitted("github.com/noisy/lib", api.SeverityDebug, tenant))
+	assert.False(t, emitted("github.com/noisy/lib", api.SeverityDebug, attribute.String("tenant", "other")))
+
+	assert.False(t, emitted("github.com/app", api.SeverityDebug))
+	assert.True(t, emitted("github.com/app", api.SeverityInfo))
+	assert.True(t, emitted("github.com/app", api.SeverityTrace, tenant))
+
+	noisy.Set(SeverityInfo)
+	assert.True(t, emitted("github.com/noisy/lib", api.SeverityInfo), "rule severity should be dynamic")
+}
@@ -9,4 +9,8 @@
 // TextMapPropagator with useful defaults (a combined TraceContext and Baggage
 // TextMapPropagator), and supports environment overrides using the
 // OTEL_PROPAGATORS environment variable.
+//
+// NewPrecedenceTextMapPropagator returns a TextMapPropagator extracting the
+// span context of the first configured propagator finding one, instead of the
+// last one, and can report which propagator was used.
 package autoprop
@@ -4,12 +4,14 @@
 package autoprop_test
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"sort"
 
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
 
 	"go.opentelemetry.io/contrib/propagators/autoprop"
 	"go.opentelemetry.io/contrib/propagators/b3"
@@ -100,3 +102,27 @@ func ExampleGetTextMapPropagator_custom() {
 	fmt.Println(prop.Fields())
 	// Output: [my-header-val]
 }
+
+func ExampleNewPrecedenceTextMapPropagator() {
+	// Prefer the B3 span context while services migrate to TraceContext, and
+	// report the requests where both are present with different traces.
+	_ = os.Setenv("OTEL_PROPAGATORS", "b3,tracecontext")
+
+	prop := autoprop.NewPrecedenceTextMapPropagator(
+		autoprop.WithExtractHook(func(_ context.Context, r autoprop.ExtractResult) {
+			fmt.Println("extracted by:", r.Propagator)
+			fmt.Println("conflicts:", r.Conflicts)
+		}),
+	)
+
+	carrier := propagation.MapCarrier{
+		"b3":          "80f198ee56343ba864fe8b2a57d3eff7-e457b5a2e4d86bd1-1",
+		"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+	}
+	ctx := prop.Extract(context.Background(), carrier)
+	fmt.Println("trace ID:", trace.SpanContextFromContext(ctx).TraceID())
+	// Output:
+	// extracted by: b3
+	// conflicts: [tracecontext]
+	// trace ID: 80f198ee56343ba864fe8b2a57d3eff7
+}
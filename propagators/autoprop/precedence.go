@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autoprop
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ExtractResult describes the span context extraction of a TextMapPropagator
+// returned by NewPrecedenceTextMapPropagator.
+type ExtractResult struct {
+	// Propagator is the name of the propagator the span context was
+	// extracted with. It is empty if no span context was extracted.
+	//
+	// The propagators set with the OTEL_PROPAGATORS environment variable are
+	// named as in the variable. Other propagators are named as registered, see
+	// RegisterTextMapPropagator, or by their type if they are not registered.
+	Propagator string
+
+	// SpanContext is the extracted span context.
+	SpanContext trace.SpanContext
+
+	// Conflicts are the names of the propagators that extracted a span
+	// context with a different trace ID than SpanContext. Their span contexts
+	// are ignored.
+	Conflicts []string
+}
+
+// Option configures a TextMapPropagator returned by
+// NewPrecedenceTextMapPropagator.
+type Option interface {
+	apply(config) config
+}
+
+type config struct {
+	props []propagation.TextMapPropagator
+	hook  func(context.Context, ExtractResult)
+}
+
+type optFunc func(config) config
+
+func (f optFunc) apply(c config) config { return f(c) }
+
+// WithPropagators sets the propagators used if the OTEL_PROPAGATORS
+// environment variable is not set, in order of precedence. By default, the
+// TraceContext and Baggage propagators are used.
+func WithPropagators(props ...propagation.TextMapPropagator) Option {
+	return optFunc(func(c config) config {
+		c.props = props
+		return c
+	})
+}
+
+// WithExtractHook sets a function called with the context passed to Extract
+// and the result of every extraction. It can be used to log or record
+// metrics of the propagators in use and their disagreements.
+//
+// The function is called synchronously and must not block.
+func WithExtractHook(f func(context.Context, ExtractResult)) Option {
+	return optFunc(func(c config) config {
+		c.hook = f
+		return c
+	})
+}
+
+// NewPrecedenceTextMapPropagator returns a TextMapPropagator like
+// NewTextMapPropagator, except for the extraction of the span context.
+//
+// The composite TextMapPropagator returned by NewTextMapPropagator extracts
+// the span context of the last propagator finding one. The returned
+// TextMapPropagator instead extracts the span context of the first
+// propagator finding one, in the order of the OTEL_PROPAGATORS environment
+// variable or of WithPropagators. The propagators after it still extract the
+// other values, such as baggage, but a span context they extract is ignored.
+//
+// All the propagators inject the span context.
+func NewPrecedenceTextMapPropagator(opts ...Option) propagation.TextMapPropagator {
+	var cfg config
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+
+	var named []namedPropagator
+	if names := envNames(); len(names) > 0 {
+		var (
+			isNone bool
+			err    error
+		)
+		named, isNone, err = loadNamed(names)
+		if err != nil {
+			// Communicate to the user their supplied value will not be used.
+			otel.Handle(err)
+		}
+		if isNone {
+			return noopPropagator
+		}
+	}
+	if len(named) == 0 {
+		props := cfg.props
+		if len(props) == 0 {
+			props = []propagation.TextMapPropagator{propagation.TraceContext{}, propagation.Baggage{}}
+		}
+		named = make([]namedPropagator, len(props))
+		for i, p := range props {
+			named[i] = namedPropagator{name: propagators.nameOf(p), TextMapPropagator: p}
+		}
+	}
+	return &precedencePropagator{props: named, hook: cfg.hook}
+}
+
+// precedencePropagator is a composite TextMapPropagator extracting the span
+// context of the first propagator finding one.
+type precedencePropagator struct {
+	props []namedPropagator
+	hook  func(context.Context, ExtractResult)
+}
+
+var _ propagation.TextMapPropagator = (*precedencePropagator)(nil)
+
+// Inject injects ctx into carrier with all the propagators.
+func (p *precedencePropagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	for _, prop := range p.props {
+		prop.Inject(ctx, carrier)
+	}
+}
+
+// Extract extracts the values of carrier into ctx with all the propagators,
+// using the span context of the first propagator finding one.
+func (p *precedencePropagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	parent := ctx
+	prev := trace.SpanContextFromContext(ctx)
+
+	var res ExtractResult
+	for _, prop := range p.props {
+		next := prop.Extract(ctx, carrier)
+		sc := trace.SpanContextFromContext(next)
+		if res.Propagator == "" {
+			if sc.IsValid() && !sc.Equal(prev) {
+				res.Propagator = prop.name
+				res.SpanContext = sc
+			}
+			ctx = next
+			continue
+		}
+
+		ctx = next
+		if sc.Equal(res.SpanContext) {
+			// No span context was extracted.
+			continue
+		}
+		if sc.TraceID() != res.SpanContext.TraceID() {
+			res.Conflicts = append(res.Conflicts, prop.name)
+		}
+		// Keep the other values extracted, such as baggage, but restore
+		// the chosen span context.
+		ctx = trace.ContextWithRemoteSpanContext(ctx, res.SpanContext)
+	}
+
+	if p.hook != nil {
+		p.hook(parent, res)
+	}
+	return ctx
+}
+
+// Fields returns the union of the fields of all the propagators.
+func (p *precedencePropagator) Fields() []string {
+	unique := make(map[string]struct{})
+	var fields []string
+	for _, prop := range p.props {
+		for _, f := range prop.Fields() {
+			if _, ok := unique[f]; !ok {
+				unique[f] = struct{}{}
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
@@ -0,0 +1,160 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package autoprop
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/jaeger"
+)
+
+const (
+	w3cTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	b3TraceID  = "80f198ee56343ba864fe8b2a57d3eff7"
+)
+
+var conflicting = propagation.MapCarrier{
+	"traceparent": "00-" + w3cTraceID + "-00f067aa0ba902b7-01",
+	"b3":          b3TraceID + "-e457b5a2e4d86bd1-1",
+	"baggage":     "key=value",
+}
+
+func TestPrecedenceExtractFirstMatch(t *testing.T) {
+	var got []ExtractResult
+	hook := func(_ context.Context, r ExtractResult) { got = append(got, r) }
+
+	t.Setenv(otelPropagatorsEnvKey, "b3,tracecontext,baggage")
+	p := NewPrecedenceTextMapPropagator(WithExtractHook(hook))
+	ctx := p.Extract(t.Context(), conflicting)
+
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, b3TraceID, sc.TraceID().String())
+	assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+
+	require.Len(t, got, 1)
+	assert.Equal(t, "b3", got[0].Propagator)
+	assert.True(t, got[0].SpanContext.Equal(sc))
+	assert.Equal(t, []string{"tracecontext"}, got[0].Conflicts)
+
+	// The composite propagator uses the last span context.
+	composite := NewTextMapPropagator()
+	sc = trace.SpanContextFromContext(composite.Extract(t.Context(), conflicting))
+	assert.Equal(t, w3cTraceID, sc.TraceID().String())
+}
+
+func TestPrecedenceExtractNames(t *testing.T) {
+	var got ExtractResult
+	hook := func(_ context.Context, r ExtractResult) { got = r }
+
+	p := NewPrecedenceTextMapPropagator(
+		WithPropagators(propagation.TraceContext{}, b3.New(), propagation.Baggage{}),
+		WithExtractHook(hook),
+	)
+	ctx := p.Extract(t.Context(), conflicting)
+	assert.Equal(t, w3cTraceID, trace.SpanContextFromContext(ctx).TraceID().String())
+	assert.Equal(t, "tracecontext", got.Propagator)
+	assert.Equal(t, []string{"b3"}, got.Conflicts)
+
+	type unregistered struct{ propagation.TraceContext }
+	p = NewPrecedenceTextMapPropagator(
+		WithPropagators(unregistered{}),
+		WithExtractHook(hook),
+	)
+	p.Extract(t.Context(), conflicting)
+	assert.Equal(t, "autoprop.unregistered", got.Propagator)
+}
+
+func TestPrecedenceExtractAgreement(t *testing.T) {
+	var got ExtractResult
+	hook := func(_ context.Context, r ExtractResult) { got = r }
+
+	carrier := propagation.MapCarrier{
+		"traceparent": "00-" + w3cTraceID + "-00f067aa0ba902b7-01",
+		"b3":          w3cTraceID + "-e457b5a2e4d86bd1-1",
+	}
+	p := NewPrecedenceTextMapPropagator(
+		WithPropagators(propagation.TraceContext{}, b3.New()),
+		WithExtractHook(hook),
+	)
+	ctx := p.Extract(t.Context(), carrier)
+	assert.Equal(t, "00f067aa0ba902b7", trace.SpanContextFromContext(ctx).SpanID().String())
+	assert.Equal(t, "tracecontext", got.Propagator)
+	assert.Empty(t, got.Conflicts, "same trace ID is not a conflict")
+}
+
+func TestPrecedenceExtractConflictValues(t *testing.T) {
+	var got ExtractResult
+	hook := func(_ context.Context, r ExtractResult) { got = r }
+
+	carrier := propagation.MapCarrier{
+		"traceparent":   "00-" + w3cTraceID + "-00f067aa0ba902b7-01",
+		"uber-trace-id": b3TraceID + ":e457b5a2e4d86bd1:0:1",
+		"uberctx-user":  "alice",
+	}
+	p := NewPrecedenceTextMapPropagator(
+		WithPropagators(propagation.TraceContext{}, jaeger.Jaeger{}),
+		WithExtractHook(hook),
+	)
+	ctx := p.Extract(t.Context(), carrier)
+
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, w3cTraceID, sc.TraceID().String())
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, []string{"jaeger"}, got.Conflicts)
+	assert.Equal(t, "alice", baggage.FromContext(ctx).Member("user").Value(), "baggage of a conflicting propagator should be kept")
+}
+
+func TestPrecedenceExtractNoMatch(t *testing.T) {
+	called := false
+	var got ExtractResult
+	hook := func(_ context.Context, r ExtractResult) {
+		called = true
+		got = r
+	}
+
+	p := NewPrecedenceTextMapPropagator(WithExtractHook(hook))
+	ctx := p.Extract(t.Context(), propagation.MapCarrier{"baggage": "key=value"})
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.Equal(t, "value", baggage.FromContext(ctx).Member("key").Value())
+	assert.True(t, called)
+	assert.Empty(t, got.Propagator)
+}
+
+func TestPrecedenceInjectAndFields(t *testing.T) {
+	p := NewPrecedenceTextMapPropagator(
+		WithPropagators(propagation.TraceContext{}, b3.New(), propagation.TraceContext{}),
+	)
+	assert.Equal(t, []string{"traceparent", "tracestate", "b3"}, p.Fields())
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+	})
+	carrier := propagation.MapCarrier{}
+	p.Inject(trace.ContextWithSpanContext(t.Context(), sc), carrier)
+	assert.NotEmpty(t, carrier.Get("traceparent"))
+	assert.NotEmpty(t, carrier.Get("b3"))
+}
+
+func TestPrecedenceEnv(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "b3,none")
+	assert.Equal(t, noop, NewPrecedenceTextMapPropagator())
+
+	h := &handler{}
+	otel.SetErrorHandler(h)
+	t.Setenv(otelPropagatorsEnvKey, "invalid-name")
+	p := NewPrecedenceTextMapPropagator(WithPropagators(b3.New()))
+	assert.ErrorIs(t, h.err, errUnknownPropagator)
+	assert.Equal(t, []string{"b3"}, p.Fields(), "unknown names should fall back to WithPropagators")
+}
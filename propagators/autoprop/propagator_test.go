@@ -60,3 +60,23 @@ func TestNewTextMapPropagatorMultiEnvNone(t *testing.T) {
 	t.Setenv(otelPropagatorsEnvKey, "b3,none,tracecontext")
 	assert.Equal(t, noop, NewTextMapPropagator())
 }
+
+func TestNewTextMapPropagatorEnvDatadog(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "tracecontext,datadog")
+	expect := []string{
+		"traceparent",
+		"tracestate",
+		"x-datadog-trace-id",
+		"x-datadog-parent-id",
+		"x-datadog-sampling-priority",
+		"x-datadog-origin",
+		"x-datadog-tags",
+	}
+	assert.ElementsMatch(t, expect, NewTextMapPropagator().Fields())
+}
+
+func TestNewTextMapPropagatorEnvCloudTrace(t *testing.T) {
+	t.Setenv(otelPropagatorsEnvKey, "tracecontext,cloudtrace")
+	expect := []string{"traceparent", "tracestate", "X-Cloud-Trace-Context"}
+	assert.ElementsMatch(t, expect, NewTextMapPropagator().Fields())
+}
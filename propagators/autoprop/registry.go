@@ -6,6 +6,9 @@ package autoprop
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
 	"strings"
 	"sync"
 
@@ -13,6 +16,8 @@ import (
 
 	"go.opentelemetry.io/contrib/propagators/aws/xray"
 	"go.opentelemetry.io/contrib/propagators/b3"
+	"go.opentelemetry.io/contrib/propagators/cloudtrace"
+	"go.opentelemetry.io/contrib/propagators/datadog"
 	"go.opentelemetry.io/contrib/propagators/jaeger"
 	"go.opentelemetry.io/contrib/propagators/ot"
 )
@@ -44,6 +49,10 @@ var propagators = &registry{
 		"xray": xray.Propagator{},
 		// OpenTracing Trace.
 		"ottrace": ot.OT{},
+		// Datadog.
+		"datadog": datadog.Propagator{},
+		// Google Cloud X-Cloud-Trace-Context.
+		"cloudtrace": cloudtrace.Propagator{},
 
 		// No-op TextMapPropagator.
 		none: noopPropagator,
@@ -96,7 +105,8 @@ func (r *registry) drop(key string) {
 // RegisterTextMapPropagator sets the TextMapPropagator p to be used when the
 // OTEL_PROPAGATORS environment variable contains the propagator name. This
 // will panic if name has already been registered or is a default
-// (tracecontext, baggage, b3, b3multi, jaeger, xray, or ottrace).
+// (tracecontext, baggage, b3, b3multi, jaeger, xray, ottrace, datadog, or
+// cloudtrace).
 func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 	if err := propagators.store(name, p); err != nil {
 		// envRegistry.store will return errDupReg if name is already
@@ -116,7 +126,7 @@ func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 // passed names of registered TextMapPropagators. Each name must match an
 // already registered TextMapPropagator (see the RegisterTextMapPropagator
 // function for more information) or a default (tracecontext, baggage, b3,
-// b3multi, jaeger, xray, or ottrace).
+// b3multi, jaeger, xray, ottrace, datadog, or cloudtrace).
 //
 // If "none" is included in the arguments, or no names are provided, the
 // returned TextMapPropagator will be a no-operation implementation.
@@ -126,17 +136,53 @@ func RegisterTextMapPropagator(name string, p propagation.TextMapPropagator) {
 // error. If every provided name is un-registered, a nil TextMapPropagator is
 // returned with the error so callers can fall back to their own default.
 func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
-	var (
-		props   []propagation.TextMapPropagator
-		unknown []string
-	)
+	named, isNone, err := loadNamed(names)
+	if isNone {
+		return noopPropagator, nil
+	}
+
+	switch len(named) {
+	case 0:
+		if err != nil {
+			// Names were provided but none matched a registered propagator.
+			// Return nil so callers such as NewTextMapPropagator can fall back
+			// to their default rather than silently disabling propagation.
+			return nil, err
+		}
+		// No names were provided. Return the no-op propagator, matching the
+		// "none" behavior above.
+		return noopPropagator, nil
+	case 1:
+		// Do not return a composite of a single propagator.
+		return named[0].TextMapPropagator, err
+	default:
+		props := make([]propagation.TextMapPropagator, len(named))
+		for i, p := range named {
+			props[i] = p.TextMapPropagator
+		}
+		return propagation.NewCompositeTextMapPropagator(props...), err
+	}
+}
 
+// namedPropagator is a TextMapPropagator with the name identifying it in
+// diagnostics.
+type namedPropagator struct {
+	name string
+	propagation.TextMapPropagator
+}
+
+// loadNamed returns the registered TextMapPropagators of names. If "none" is
+// included in names, isNone is true and no propagator is returned. An error
+// is returned for any un-registered names, the known names are returned with
+// it.
+func loadNamed(names []string) (named []namedPropagator, isNone bool, err error) {
+	var unknown []string
 	for _, name := range names {
 		if name == none {
 			// If "none" is passed in combination with any other propagator,
 			// the result still needs to be a no-op propagator. Therefore,
 			// short-circuit here.
-			return noopPropagator, nil
+			return nil, true, nil
 		}
 
 		p, ok := propagators.load(name)
@@ -144,30 +190,32 @@ func TextMapPropagator(names ...string) (propagation.TextMapPropagator, error) {
 			unknown = append(unknown, name)
 			continue
 		}
-		props = append(props, p)
+		named = append(named, namedPropagator{name: name, TextMapPropagator: p})
 	}
 
-	var err error
 	if len(unknown) > 0 {
 		joined := strings.Join(unknown, ",")
 		err = fmt.Errorf("%w: %s", errUnknownPropagator, joined)
 	}
+	return named, false, err
+}
 
-	switch len(props) {
-	case 0:
-		if err != nil {
-			// Names were provided but none matched a registered propagator.
-			// Return nil so callers such as NewTextMapPropagator can fall back
-			// to their default rather than silently disabling propagation.
-			return nil, err
+// nameOf returns the registered name of p, or its type if p is not
+// registered.
+func (r *registry) nameOf(p propagation.TextMapPropagator) string {
+	if v := reflect.ValueOf(p); v.IsValid() && v.Comparable() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		// Sorted for a deterministic name if equal propagators are
+		// registered.
+		for _, name := range slices.Sorted(maps.Keys(r.names)) {
+			if name == none {
+				continue
+			}
+			if w := reflect.ValueOf(r.names[name]); w.Comparable() && v.Equal(w) {
+				return name
+			}
 		}
-		// No names were provided. Return the no-op propagator, matching the
-		// "none" behavior above.
-		return noopPropagator, nil
-	case 1:
-		// Do not return a composite of a single propagator.
-		return props[0], err
-	default:
-		return propagation.NewCompositeTextMapPropagator(props...), err
 	}
+	return fmt.Sprintf("%T", p)
 }
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package xray
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type stateKeyType int
+
+const stateKey stateKeyType = iota
+
+// headerState is the state of an extracted X-Amzn-Trace-Id header that is not
+// held by its span context.
+type headerState struct {
+	// traceID is the trace ID of the header.
+	traceID trace.TraceID
+	// deferred is true if the sampling decision was deferred with Sampled=?.
+	deferred bool
+	// fields are the key=value fields of the header other than Root, Parent
+	// and Sampled, in their order.
+	fields []string
+}
+
+// contextWithState returns a copy of parent holding state.
+func contextWithState(parent context.Context, state headerState) context.Context {
+	return context.WithValue(parent, stateKey, state)
+}
+
+// stateFromContext returns the header state stored in ctx if it is the state
+// of the trace traceID. Otherwise, the zero headerState is returned.
+func stateFromContext(ctx context.Context, traceID trace.TraceID) headerState {
+	if ctx == nil {
+		return headerState{}
+	}
+	state, ok := ctx.Value(stateKey).(headerState)
+	if !ok || state.traceID != traceID {
+		return headerState{}
+	}
+	return state
+}
+
+// SamplingDeferred returns true if ctx holds a span context extracted by the
+// [Propagator] from a header deferring the sampling decision to the receiver
+// with Sampled=?. The extracted span context is not sampled; a sampler can
+// use SamplingDeferred on the parent context of a span to make the decision
+// instead of following the one of the parent.
+func SamplingDeferred(ctx context.Context) bool {
+	sc := trace.SpanContextFromContext(ctx)
+	return sc.IsRemote() && stateFromContext(ctx, sc.TraceID()).deferred
+}
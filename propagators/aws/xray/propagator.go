@@ -8,6 +8,7 @@ package xray
 import (
 	"context"
 	"errors"
+	"os"
 	"strings"
 
 	"go.opentelemetry.io/otel/propagation"
@@ -25,6 +26,12 @@ const (
 	traceIDDelimiter     = "-"
 	isSampled            = "1"
 	notSampled           = "0"
+	deferredSampling     = "?"
+
+	// lambdaTraceEnvKey is the environment variable holding the
+	// X-Amzn-Trace-Id header value of the current invocation of a Lambda
+	// function.
+	lambdaTraceEnvKey = "_X_AMZN_TRACE_ID"
 
 	traceFlagNone           = 0x0
 	traceFlagSampled        = 0x1 << 0
@@ -49,6 +56,12 @@ var (
 // Example AWS X-Ray format:
 //
 // X-Amzn-Trace-Id: Root={traceId};Parent={parentId};Sampled={samplingFlag}.
+//
+// The other fields of an extracted header, such as the Lineage field used by
+// AWS services to detect loops, are kept in the returned context and injected
+// with the span context of the same trace. A Sampled=? field, deferring the
+// sampling decision to the receiver, is extracted as a not sampled span
+// context, see [SamplingDeferred].
 type Propagator struct{}
 
 // Asserts that the propagator implements the otel.TextMapPropagator interface at compile time.
@@ -64,14 +77,22 @@ func (Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier
 	xrayTraceID := traceIDVersion + traceIDDelimiter + otTraceID[0:traceIDFirstPartLength] +
 		traceIDDelimiter + otTraceID[traceIDFirstPartLength:]
 	parentID := sc.SpanID()
+	state := stateFromContext(ctx, sc.TraceID())
 	samplingFlag := notSampled
-	if sc.TraceFlags().IsSampled() {
+	switch {
+	case sc.TraceFlags().IsSampled():
 		samplingFlag = isSampled
+	case state.deferred && sc.IsRemote():
+		// No decision was made since the span context was extracted.
+		samplingFlag = deferredSampling
 	}
 	headers := []string{
 		traceIDKey, kvDelimiter, xrayTraceID, traceHeaderDelimiter, parentIDKey,
 		kvDelimiter, parentID.String(), traceHeaderDelimiter, sampleFlagKey, kvDelimiter, samplingFlag,
 	}
+	for _, field := range state.fields {
+		headers = append(headers, traceHeaderDelimiter, field)
+	}
 
 	carrier.Set(traceHeaderKey, strings.Join(headers, ""))
 }
@@ -80,18 +101,34 @@ func (Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier
 func (Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
 	// extract tracing information
 	if header := carrier.Get(traceHeaderKey); header != "" {
-		sc, err := extract(header)
+		sc, state, err := extract(header)
 		if err == nil && sc.IsValid() {
+			if state.deferred || len(state.fields) > 0 {
+				ctx = contextWithState(ctx, state)
+			}
 			return trace.ContextWithRemoteSpanContext(ctx, sc)
 		}
 	}
 	return ctx
 }
 
+// ExtractFromLambdaEnv returns a copy of ctx holding the span context of the
+// X-Amzn-Trace-Id header value that the Lambda runtime sets in the
+// _X_AMZN_TRACE_ID environment variable for the current invocation. ctx is
+// returned if the variable is not set or invalid.
+func ExtractFromLambdaEnv(ctx context.Context) context.Context {
+	header := os.Getenv(lambdaTraceEnvKey)
+	if header == "" {
+		return ctx
+	}
+	return Propagator{}.Extract(ctx, propagation.MapCarrier{traceHeaderKey: header})
+}
+
 // extract extracts Span Context from context.
-func extract(headerVal string) (trace.SpanContext, error) {
+func extract(headerVal string) (trace.SpanContext, headerState, error) {
 	var (
 		scc            = trace.SpanContextConfig{}
+		state          headerState
 		err            error
 		delimiterIndex int
 		part           string
@@ -109,27 +146,33 @@ func extract(headerVal string) (trace.SpanContext, error) {
 		}
 		_, after, ok := strings.Cut(part, kvDelimiter)
 		if !ok {
-			return empty, errInvalidTraceHeader
+			return empty, headerState{}, errInvalidTraceHeader
 		}
 		value := after
 		switch {
 		case strings.HasPrefix(part, traceIDKey):
 			scc.TraceID, err = parseTraceID(value)
 			if err != nil {
-				return empty, err
+				return empty, headerState{}, err
 			}
 		case strings.HasPrefix(part, parentIDKey):
 			// extract parentId
 			scc.SpanID, err = trace.SpanIDFromHex(value)
 			if err != nil {
-				return empty, errInvalidSpanIDLength
+				return empty, headerState{}, errInvalidSpanIDLength
 			}
 		case strings.HasPrefix(part, sampleFlagKey):
 			// extract traceflag
 			scc.TraceFlags = parseTraceFlag(value)
+			state.deferred = value == deferredSampling
+		default:
+			// Keep the other fields, such as Lineage, to pass them
+			// downstream.
+			state.fields = append(state.fields, strings.TrimSpace(part))
 		}
 	}
-	return trace.NewSpanContext(scc), nil
+	state.traceID = scc.TraceID
+	return trace.NewSpanContext(scc), state, nil
 }
 
 // indexOf returns position of the first occurrence of a substr in str starting at pos index.
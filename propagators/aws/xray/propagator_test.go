@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
@@ -345,3 +346,76 @@ func TestPropagatorFields(t *testing.T) {
 	assert.Len(t, propagator.Fields(), 1, "Fields() should return exactly one field")
 	assert.Equal(t, []string{traceHeaderKey}, propagator.Fields())
 }
+
+func TestPropagateAdditionalFields(t *testing.T) {
+	const header = "Root=1-abcdef12-1234567890abcdef12345678;Parent=1234567890abcdef;Sampled=1;Lineage=a87bd80c:1|68fd508a:5;Foo=bar"
+	p := Propagator{}
+
+	ctx := p.Extract(t.Context(), propagation.HeaderCarrier{traceHeaderKey: []string{header}})
+	sc := trace.SpanContextFromContext(ctx)
+	require.True(t, sc.IsValid())
+	assert.True(t, sc.IsSampled())
+
+	// A child span of the extracted span context keeps the fields.
+	child := sc.WithSpanID(trace.SpanID{0xfe, 0xdc, 0xba, 0x09, 0x87, 0x65, 0x43, 0x21}).WithRemote(false)
+	carrier := propagation.MapCarrier{}
+	p.Inject(trace.ContextWithSpanContext(ctx, child), carrier)
+	assert.Equal(t,
+		"Root=1-abcdef12-1234567890abcdef12345678;Parent=fedcba0987654321;Sampled=1;Lineage=a87bd80c:1|68fd508a:5;Foo=bar",
+		carrier.Get(traceHeaderKey),
+	)
+
+	// The fields are not injected with the span context of another trace.
+	other := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x01},
+		SpanID:  trace.SpanID{0x01},
+	})
+	carrier = propagation.MapCarrier{}
+	p.Inject(trace.ContextWithSpanContext(ctx, other), carrier)
+	assert.NotContains(t, carrier.Get(traceHeaderKey), "Lineage")
+}
+
+func TestSamplingDeferred(t *testing.T) {
+	const header = "Root=1-abcdef12-1234567890abcdef12345678;Parent=1234567890abcdef;Sampled=?"
+	p := Propagator{}
+
+	ctx := p.Extract(t.Context(), propagation.HeaderCarrier{traceHeaderKey: []string{header}})
+	sc := trace.SpanContextFromContext(ctx)
+	require.True(t, sc.IsValid())
+	assert.False(t, sc.IsSampled())
+	assert.True(t, SamplingDeferred(ctx))
+
+	// The decision is still deferred when propagating the extracted span
+	// context.
+	carrier := propagation.MapCarrier{}
+	p.Inject(ctx, carrier)
+	assert.Equal(t, header, carrier.Get(traceHeaderKey))
+
+	// A local decision is propagated.
+	for _, flags := range []trace.TraceFlags{trace.FlagsSampled, 0} {
+		child := sc.WithTraceFlags(flags).WithRemote(false)
+		childCtx := trace.ContextWithSpanContext(ctx, child)
+		assert.False(t, SamplingDeferred(childCtx))
+		carrier = propagation.MapCarrier{}
+		p.Inject(childCtx, carrier)
+		assert.NotContains(t, carrier.Get(traceHeaderKey), "Sampled=?")
+	}
+
+	ctx = p.Extract(t.Context(), propagation.HeaderCarrier{
+		traceHeaderKey: []string{"Root=1-abcdef12-1234567890abcdef12345678;Parent=1234567890abcdef;Sampled=0"},
+	})
+	assert.False(t, SamplingDeferred(ctx))
+}
+
+func TestExtractFromLambdaEnv(t *testing.T) {
+	t.Setenv(lambdaTraceEnvKey, "Root=1-abcdef12-1234567890abcdef12345678;Parent=1234567890abcdef;Sampled=1;Lineage=a87bd80c:1")
+	ctx := ExtractFromLambdaEnv(t.Context())
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsValid())
+	assert.True(t, sc.IsRemote())
+	assert.Equal(t, "abcdef121234567890abcdef12345678", sc.TraceID().String())
+	assert.Equal(t, []string{"Lineage=a87bd80c:1"}, stateFromContext(ctx, sc.TraceID()).fields)
+
+	t.Setenv(lambdaTraceEnvKey, "")
+	assert.Equal(t, t.Context(), ExtractFromLambdaEnv(t.Context()))
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudtrace
+
+import (
+	"context"
+	"encoding/binary"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// cloudTraceHeader is the header used by the OpenCensus Stackdriver
+// propagation format:
+// https://github.com/census-instrumentation/opencensus-go/blob/master/plugin/ochttp/propagation/tracecontext/propagation.go
+const cloudTraceHeader = "X-Cloud-Trace-Context"
+
+// Propagator serializes SpanContext to/from the Google Cloud
+// X-Cloud-Trace-Context header used by the OpenCensus Stackdriver
+// propagation:
+//
+//	X-Cloud-Trace-Context: TRACE_ID/SPAN_ID;o=OPTIONS
+//
+// TRACE_ID is the hex encoded trace ID, SPAN_ID is the decimal span ID and
+// OPTIONS is 1 if the trace is sampled and 0 otherwise. The options are
+// optional when extracting.
+//
+// The format does not carry the W3C trace state. When composed with the W3C
+// Trace Context propagator, listed first, the trace state it extracted is
+// kept for the same trace.
+type Propagator struct{}
+
+var _ propagation.TextMapPropagator = Propagator{}
+
+// Inject injects context into the TextMapCarrier.
+func (Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	sid := sc.SpanID()
+	opts := "0"
+	if sc.IsSampled() {
+		opts = "1"
+	}
+	carrier.Set(cloudTraceHeader, sc.TraceID().String()+"/"+
+		strconv.FormatUint(binary.BigEndian.Uint64(sid[:]), 10)+";o="+opts)
+}
+
+// Extract extracts the SpanContext from the TextMapCarrier. The trace state of
+// a span context of the same trace already in ctx is preserved.
+func (p Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	sc := p.extract(carrier)
+	if !sc.IsValid() {
+		return ctx
+	}
+	if prev := trace.SpanContextFromContext(ctx); prev.TraceID() == sc.TraceID() {
+		sc = sc.WithTraceState(prev.TraceState())
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+func (Propagator) extract(carrier propagation.TextMapCarrier) trace.SpanContext {
+	h := carrier.Get(cloudTraceHeader)
+	if h == "" {
+		return trace.SpanContext{}
+	}
+	ids, opts, _ := strings.Cut(h, ";")
+	tid, sid, ok := strings.Cut(ids, "/")
+	if !ok {
+		return trace.SpanContext{}
+	}
+
+	var (
+		scc trace.SpanContextConfig
+		err error
+	)
+	if scc.TraceID, err = trace.TraceIDFromHex(tid); err != nil {
+		return trace.SpanContext{}
+	}
+	spanID, err := strconv.ParseUint(sid, 10, 64)
+	if err != nil {
+		return trace.SpanContext{}
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], spanID)
+
+	// Only the sampled bit of the options is defined.
+	if o, ok := strings.CutPrefix(opts, "o="); ok {
+		if n, err := strconv.ParseUint(o, 10, 8); err == nil && n&1 == 1 {
+			scc.TraceFlags = trace.FlagsSampled
+		}
+	}
+	return trace.NewSpanContext(scc)
+}
+
+// Fields returns the fields that this propagator modifies.
+func (Propagator) Fields() []string {
+	return []string{cloudTraceHeader}
+}
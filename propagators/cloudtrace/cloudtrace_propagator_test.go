@@ -0,0 +1,141 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudtrace
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const traceIDHex = "0e360c00000000000000000000000000"
+
+var (
+	traceID = trace.TraceID{14, 54, 12}
+	spanID  = trace.SpanID{0, 0, 0, 0, 0, 0, 0, 2}
+)
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{"X-Cloud-Trace-Context"}, Propagator{}.Fields())
+}
+
+func TestInject(t *testing.T) {
+	tests := []struct {
+		name string
+		scc  trace.SpanContextConfig
+		want string
+	}{
+		{
+			name: "invalid span context",
+			scc:  trace.SpanContextConfig{},
+			want: "",
+		},
+		{
+			name: "sampled",
+			scc:  trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			want: traceIDHex + "/2;o=1",
+		},
+		{
+			name: "not sampled",
+			scc:  trace.SpanContextConfig{TraceID: traceID, SpanID: spanID},
+			want: traceIDHex + "/2;o=0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			ctx := trace.ContextWithRemoteSpanContext(t.Context(), trace.NewSpanContext(tt.scc))
+			Propagator{}.Inject(ctx, propagation.HeaderCarrier(header))
+
+			assert.Equal(t, tt.want, header.Get("X-Cloud-Trace-Context"))
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   trace.SpanContextConfig
+	}{
+		{
+			name:   "empty",
+			header: "",
+		},
+		{
+			name:   "sampled",
+			header: traceIDHex + "/2;o=1",
+			want:   trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled, Remote: true},
+		},
+		{
+			name:   "not sampled",
+			header: traceIDHex + "/2;o=0",
+			want:   trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, Remote: true},
+		},
+		{
+			name:   "no options",
+			header: traceIDHex + "/2",
+			want:   trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, Remote: true},
+		},
+		{
+			name:   "unknown options",
+			header: traceIDHex + "/2;o=3",
+			want:   trace.SpanContextConfig{TraceID: traceID, SpanID: spanID, TraceFlags: trace.FlagsSampled, Remote: true},
+		},
+		{
+			name:   "missing span ID",
+			header: traceIDHex + ";o=1",
+		},
+		{
+			name:   "hex span ID",
+			header: traceIDHex + "/a0;o=1",
+		},
+		{
+			name:   "zero span ID",
+			header: traceIDHex + "/0;o=1",
+		},
+		{
+			name:   "invalid trace ID",
+			header: "0e360c/2;o=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{"X-Cloud-Trace-Context": []string{tt.header}}
+
+			ctx := Propagator{}.Extract(t.Context(), propagation.HeaderCarrier(header))
+			assert.Equal(t, trace.NewSpanContext(tt.want), trace.SpanContextFromContext(ctx))
+		})
+	}
+}
+
+func TestExtractPreservesTraceState(t *testing.T) {
+	ts, err := trace.ParseTraceState("vendor=value")
+	require.NoError(t, err)
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     trace.SpanID{1},
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+	header := http.Header{}
+	Propagator{}.Inject(trace.ContextWithSpanContext(t.Context(), sc), propagation.HeaderCarrier(header))
+
+	// A span context of the same trace, as extracted by a W3C Trace Context
+	// propagator, holds the trace state.
+	ctx := trace.ContextWithRemoteSpanContext(t.Context(), sc.WithSpanID(spanID))
+	got := trace.SpanContextFromContext(Propagator{}.Extract(ctx, propagation.HeaderCarrier(header)))
+	assert.True(t, got.Equal(sc.WithRemote(true)), "got %v, want %v", got, sc)
+
+	// The trace state of another trace is not used.
+	ctx = trace.ContextWithRemoteSpanContext(t.Context(), sc.WithTraceID(trace.TraceID{1}))
+	got = trace.SpanContextFromContext(Propagator{}.Extract(ctx, propagation.HeaderCarrier(header)))
+	assert.Zero(t, got.TraceState().Len())
+}
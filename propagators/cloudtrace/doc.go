@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package cloudtrace implements the Google Cloud X-Cloud-Trace-Context
+// propagator used by the OpenCensus Stackdriver propagation.
+//
+// It can be used together with the W3C Trace Context propagator while
+// migrating services from OpenCensus to OpenTelemetry.
+package cloudtrace
@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+type stateKeyType int
+
+const stateKey stateKeyType = iota
+
+// headerState is the state of extracted Datadog headers that is not held by
+// their span context.
+type headerState struct {
+	// traceID is the trace ID of the headers.
+	traceID trace.TraceID
+	// priority is the sampling priority, if hasPriority is true.
+	priority    int
+	hasPriority bool
+	// origin is the value of the x-datadog-origin header.
+	origin string
+	// tags are the propagated trace tags of the x-datadog-tags header,
+	// except the upper 64 bits of the trace ID.
+	tags []string
+}
+
+// contextWithState returns a copy of parent holding state.
+func contextWithState(parent context.Context, state headerState) context.Context {
+	return context.WithValue(parent, stateKey, state)
+}
+
+// stateFromContext returns the header state stored in ctx if it is the state
+// of the trace traceID. Otherwise, the zero headerState is returned.
+func stateFromContext(ctx context.Context, traceID trace.TraceID) headerState {
+	if ctx == nil {
+		return headerState{}
+	}
+	state, ok := ctx.Value(stateKey).(headerState)
+	if !ok || state.traceID != traceID {
+		return headerState{}
+	}
+	return state
+}
@@ -0,0 +1,171 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadog
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	traceIDHeader  = "x-datadog-trace-id"
+	parentIDHeader = "x-datadog-parent-id"
+	priorityHeader = "x-datadog-sampling-priority"
+	originHeader   = "x-datadog-origin"
+	tagsHeader     = "x-datadog-tags"
+
+	// traceIDUpperTag is the propagated tag holding the upper 64 bits of
+	// 128-bit trace IDs as 16 lowercase hex characters.
+	traceIDUpperTag = "_dd.p.tid"
+	tagsDelimiter   = ","
+	tagKVDelimiter  = "="
+
+	// Sampling priorities. The priorities greater than 0 keep the trace.
+	priorityAutoReject = 0
+	priorityAutoKeep   = 1
+)
+
+var (
+	errMissingTraceID  = errors.New("missing x-datadog-trace-id header")
+	errInvalidTraceID  = errors.New("invalid x-datadog-trace-id header, should be a non-zero unsigned 64-bit integer")
+	errInvalidParentID = errors.New("invalid x-datadog-parent-id header, should be a non-zero unsigned 64-bit integer")
+	errInvalidPriority = errors.New("invalid x-datadog-sampling-priority header, should be an integer")
+)
+
+// Propagator serializes SpanContext to/from Datadog headers.
+//
+// Datadog format:
+//
+//	x-datadog-trace-id: {lower 64 bits of the trace ID, in decimal}
+//	x-datadog-parent-id: {span ID, in decimal}
+//	x-datadog-sampling-priority: {priority}
+//	x-datadog-origin: {origin}
+//	x-datadog-tags: _dd.p.tid={upper 64 bits of the trace ID, in hex},{tags}
+//
+// A span context is sampled if its priority is greater than 0. The priority,
+// origin and the other propagated tags of the extracted headers are kept in
+// the returned context and injected with the span context of the same trace.
+type Propagator struct{}
+
+var _ propagation.TextMapPropagator = Propagator{}
+
+// Inject injects the span context of ctx into carrier with Datadog headers.
+func (Propagator) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return
+	}
+	tid := sc.TraceID()
+	sid := sc.SpanID()
+	state := stateFromContext(ctx, tid)
+
+	carrier.Set(traceIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(tid[8:]), 10))
+	carrier.Set(parentIDHeader, strconv.FormatUint(binary.BigEndian.Uint64(sid[:]), 10))
+
+	// Keep the extracted priority, for example a user decision, unless the
+	// span context has another sampling decision.
+	priority := priorityAutoReject
+	if sc.IsSampled() {
+		priority = priorityAutoKeep
+	}
+	if state.hasPriority && (state.priority > 0) == sc.IsSampled() {
+		priority = state.priority
+	}
+	carrier.Set(priorityHeader, strconv.Itoa(priority))
+
+	if state.origin != "" {
+		carrier.Set(originHeader, state.origin)
+	}
+
+	tags := state.tags
+	if upper := tid[:8]; binary.BigEndian.Uint64(upper) != 0 {
+		tags = append([]string{traceIDUpperTag + tagKVDelimiter + hex.EncodeToString(upper)}, tags...)
+	}
+	if len(tags) > 0 {
+		carrier.Set(tagsHeader, strings.Join(tags, tagsDelimiter))
+	}
+}
+
+// Extract extracts a context from the carrier if it contains Datadog
+// headers.
+func (Propagator) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	sc, state, err := extract(carrier)
+	if err != nil || !sc.IsValid() {
+		return ctx
+	}
+	if state.hasPriority || state.origin != "" || len(state.tags) > 0 {
+		ctx = contextWithState(ctx, state)
+	}
+	return trace.ContextWithRemoteSpanContext(ctx, sc)
+}
+
+// extract reconstructs a SpanContext from the Datadog headers of carrier.
+func extract(carrier propagation.TextMapCarrier) (trace.SpanContext, headerState, error) {
+	var (
+		scc   trace.SpanContextConfig
+		state headerState
+	)
+
+	h := carrier.Get(traceIDHeader)
+	if h == "" {
+		return trace.SpanContext{}, state, errMissingTraceID
+	}
+	lower, err := strconv.ParseUint(h, 10, 64)
+	if err != nil || lower == 0 {
+		return trace.SpanContext{}, state, errInvalidTraceID
+	}
+	binary.BigEndian.PutUint64(scc.TraceID[8:], lower)
+
+	parent, err := strconv.ParseUint(carrier.Get(parentIDHeader), 10, 64)
+	if err != nil || parent == 0 {
+		return trace.SpanContext{}, state, errInvalidParentID
+	}
+	binary.BigEndian.PutUint64(scc.SpanID[:], parent)
+
+	if h := carrier.Get(priorityHeader); h != "" {
+		state.priority, err = strconv.Atoi(h)
+		if err != nil {
+			return trace.SpanContext{}, state, errInvalidPriority
+		}
+		state.hasPriority = true
+		if state.priority > 0 {
+			scc.TraceFlags = trace.FlagsSampled
+		}
+	}
+
+	state.origin = carrier.Get(originHeader)
+
+	if h := carrier.Get(tagsHeader); h != "" {
+		for tag := range strings.SplitSeq(h, tagsDelimiter) {
+			key, value, ok := strings.Cut(tag, tagKVDelimiter)
+			if !ok {
+				continue
+			}
+			if key == traceIDUpperTag {
+				// An invalid tag is ignored, only the lower 64 bits of the
+				// trace ID are known.
+				if upper, err := hex.DecodeString(value); err == nil && len(upper) == 8 {
+					copy(scc.TraceID[:8], upper)
+				}
+				continue
+			}
+			state.tags = append(state.tags, tag)
+		}
+	}
+
+	state.traceID = scc.TraceID
+	return trace.NewSpanContext(scc), state, nil
+}
+
+// Fields returns the Datadog header keys whose values are set with Inject.
+func (Propagator) Fields() []string {
+	return []string{traceIDHeader, parentIDHeader, priorityHeader, originHeader, tagsHeader}
+}
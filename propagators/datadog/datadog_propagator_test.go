@@ -0,0 +1,220 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package datadog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	traceID64  = trace.TraceID{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x30, 0x39}
+	traceID128 = trace.TraceID{0x64, 0x0c, 0xfd, 0x8d, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0x30, 0x39}
+	spanID     = trace.SpanID{0, 0, 0, 0, 0, 0, 0x01, 0x00}
+)
+
+func TestExtract(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    trace.SpanContextConfig
+	}{
+		{
+			name: "sampled",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "1",
+			},
+			want: trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+		},
+		{
+			name: "user keep",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "2",
+			},
+			want: trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+		},
+		{
+			name: "user reject",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "-1",
+			},
+			want: trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+		},
+		{
+			name: "no priority",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+			},
+			want: trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+		},
+		{
+			name: "128-bit trace ID",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "1",
+				tagsHeader:     "_dd.p.dm=-4,_dd.p.tid=640cfd8d00000000",
+			},
+			want: trace.SpanContextConfig{TraceID: traceID128, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+		},
+		{
+			name: "invalid upper trace ID tag",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				tagsHeader:     "_dd.p.tid=invalid",
+			},
+			want: trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+		},
+		{
+			name:    "missing trace ID",
+			headers: map[string]string{parentIDHeader: "256"},
+		},
+		{
+			name:    "zero trace ID",
+			headers: map[string]string{traceIDHeader: "0", parentIDHeader: "256"},
+		},
+		{
+			name:    "invalid trace ID",
+			headers: map[string]string{traceIDHeader: "-12345", parentIDHeader: "256"},
+		},
+		{
+			name:    "missing parent ID",
+			headers: map[string]string{traceIDHeader: "12345"},
+		},
+		{
+			name:    "invalid parent ID",
+			headers: map[string]string{traceIDHeader: "12345", parentIDHeader: "abc"},
+		},
+		{
+			name: "invalid priority",
+			headers: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "keep",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := Propagator{}.Extract(t.Context(), propagation.MapCarrier(tt.headers))
+			got := trace.SpanContextFromContext(ctx)
+			want := trace.NewSpanContext(tt.want)
+			if want.IsValid() {
+				want = want.WithRemote(true)
+			}
+			assert.True(t, want.Equal(got), "got %v, want %v", got, want)
+		})
+	}
+}
+
+func TestInject(t *testing.T) {
+	tests := []struct {
+		name string
+		scc  trace.SpanContextConfig
+		want map[string]string
+	}{
+		{
+			name: "sampled",
+			scc:  trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			want: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "1",
+			},
+		},
+		{
+			name: "not sampled",
+			scc:  trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID},
+			want: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "0",
+			},
+		},
+		{
+			name: "128-bit trace ID",
+			scc:  trace.SpanContextConfig{TraceID: traceID128, SpanID: spanID, TraceFlags: trace.FlagsSampled},
+			want: map[string]string{
+				traceIDHeader:  "12345",
+				parentIDHeader: "256",
+				priorityHeader: "1",
+				tagsHeader:     "_dd.p.tid=640cfd8d00000000",
+			},
+		},
+		{
+			name: "invalid span context",
+			want: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			carrier := propagation.MapCarrier{}
+			ctx := trace.ContextWithSpanContext(t.Context(), trace.NewSpanContext(tt.scc))
+			Propagator{}.Inject(ctx, carrier)
+			assert.Equal(t, propagation.MapCarrier(tt.want), carrier)
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	headers := propagation.MapCarrier{
+		traceIDHeader:  "12345",
+		parentIDHeader: "256",
+		priorityHeader: "2",
+		originHeader:   "synthetics",
+		tagsHeader:     "_dd.p.dm=-4,_dd.p.tid=640cfd8d00000000",
+	}
+	p := Propagator{}
+	ctx := p.Extract(t.Context(), headers)
+	sc := trace.SpanContextFromContext(ctx)
+	require.True(t, sc.IsValid())
+
+	// A child span keeps the priority, origin and tags of the trace.
+	child := sc.WithSpanID(trace.SpanID{0, 0, 0, 0, 0, 0, 0, 0x01}).WithRemote(false)
+	carrier := propagation.MapCarrier{}
+	p.Inject(trace.ContextWithSpanContext(ctx, child), carrier)
+	assert.Equal(t, propagation.MapCarrier{
+		traceIDHeader:  "12345",
+		parentIDHeader: "1",
+		priorityHeader: "2",
+		originHeader:   "synthetics",
+		tagsHeader:     "_dd.p.tid=640cfd8d00000000,_dd.p.dm=-4",
+	}, carrier)
+
+	// A different sampling decision replaces the extracted priority.
+	carrier = propagation.MapCarrier{}
+	p.Inject(trace.ContextWithSpanContext(ctx, child.WithTraceFlags(0)), carrier)
+	assert.Equal(t, "0", carrier.Get(priorityHeader))
+
+	// The state is not injected with the span context of another trace.
+	other := trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID64, SpanID: spanID})
+	carrier = propagation.MapCarrier{}
+	p.Inject(trace.ContextWithSpanContext(ctx, other), carrier)
+	assert.Empty(t, carrier.Get(originHeader))
+	assert.Empty(t, carrier.Get(tagsHeader))
+}
+
+func TestFields(t *testing.T) {
+	assert.Equal(t, []string{
+		"x-datadog-trace-id",
+		"x-datadog-parent-id",
+		"x-datadog-sampling-priority",
+		"x-datadog-origin",
+		"x-datadog-tags",
+	}, Propagator{}.Fields())
+}
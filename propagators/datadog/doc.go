@@ -0,0 +1,9 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package datadog implements the x-datadog-* propagator used by the Datadog
+// tracing libraries.
+//
+// It can be used together with the W3C Trace Context propagator while
+// migrating services from the Datadog tracing libraries to OpenTelemetry.
+package datadog
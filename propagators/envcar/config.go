@@ -0,0 +1,47 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package envcar
+
+// config contains the options of an [Environment].
+type config struct {
+	maxBaggageBytes   int
+	maxBaggageMembers int
+}
+
+// Option configures an [Environment].
+type Option interface {
+	apply(config) config
+}
+
+type optFunc func(config) config
+
+func (f optFunc) apply(c config) config { return f(c) }
+
+// WithMaxBaggageBytes sets the maximum size in bytes of the injected BAGGAGE
+// variable. The list members that do not fit are dropped.
+//
+// By default, the W3C Baggage limit of 8192 bytes is used. Values less than
+// or equal to zero are ignored.
+func WithMaxBaggageBytes(n int) Option {
+	return optFunc(func(c config) config {
+		if n > 0 {
+			c.maxBaggageBytes = n
+		}
+		return c
+	})
+}
+
+// WithMaxBaggageMembers sets the maximum number of list members of the
+// injected BAGGAGE variable. The list members exceeding it are dropped.
+//
+// By default, the W3C Baggage limit of 64 list members is used. Values less
+// than or equal to zero are ignored.
+func WithMaxBaggageMembers(n int) Option {
+	return optFunc(func(c config) config {
+		if n > 0 {
+			c.maxBaggageMembers = n
+		}
+		return c
+	})
+}
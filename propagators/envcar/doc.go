@@ -14,6 +14,10 @@
 // environment as a live parent-context source and may observe later environment
 // variable changes.
 //
+// Use [Carrier] for the environment of the current process and [Environment]
+// for an explicit environment, such as the environment of a child process or
+// of a recorded job.
+//
 // Note that environment variables can be visible to code in the same process
 // and, on many systems, to other users or processes with sufficient
 // permissions. Do not use this carrier for sensitive context.
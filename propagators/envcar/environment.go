@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package envcar
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+const (
+	// baggageKey is the normalized name of the W3C Baggage environment
+	// variable.
+	baggageKey = "BAGGAGE"
+
+	// The W3C Baggage limits. Environment sizes are limited by the operating
+	// system, for example to 32767 characters per variable on Windows, and
+	// the whole environment counts towards the argument size limit on Linux.
+	defaultMaxBaggageBytes   = 8192
+	defaultMaxBaggageMembers = 64
+)
+
+// Environment is a [propagation.TextMapCarrier] backed by an explicit
+// environment instead of the current process environment, for example the
+// environment of a container spec, a systemd unit, a recorded CI job, or the
+// Env of an [os/exec.Cmd].
+//
+// [Environment.Get] and [Environment.Set] normalize the key the same way as
+// [Carrier]. [Environment.Keys] only lists the variable names that are already
+// normalized.
+//
+// [Environment.Set] limits the size of the injected BAGGAGE variable, see
+// [WithMaxBaggageBytes] and [WithMaxBaggageMembers]. Injected keys whose
+// normalized names collide are reported by [Environment.Collisions].
+//
+// An Environment is not safe for concurrent use.
+type Environment struct {
+	cfg  config
+	vars map[string]string
+
+	// injected maps the normalized names set by Set to their key.
+	injected   map[string]string
+	collisions []string
+}
+
+// Compile time check that Environment implements the TextMapCarrier.
+var _ propagation.TextMapCarrier = (*Environment)(nil)
+
+// NewEnvironment returns an Environment holding a copy of environ, in the
+// "key=value" form of [os.Environ] and [os/exec.Cmd.Env]. Entries without "="
+// are ignored. If a name is repeated, the last value is used.
+func NewEnvironment(environ []string, opts ...Option) *Environment {
+	vars := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			vars[k] = v
+		}
+	}
+	return newEnvironment(vars, opts)
+}
+
+// NewEnvironmentFromMap returns an Environment holding a copy of env, a map
+// of variable names to values.
+func NewEnvironmentFromMap(env map[string]string, opts ...Option) *Environment {
+	vars := make(map[string]string, len(env))
+	maps.Copy(vars, env)
+	return newEnvironment(vars, opts)
+}
+
+func newEnvironment(vars map[string]string, opts []Option) *Environment {
+	cfg := config{
+		maxBaggageBytes:   defaultMaxBaggageBytes,
+		maxBaggageMembers: defaultMaxBaggageMembers,
+	}
+	for _, opt := range opts {
+		cfg = opt.apply(cfg)
+	}
+	return &Environment{cfg: cfg, vars: vars}
+}
+
+// Get normalizes key and returns the corresponding value. It returns an empty
+// string if the normalized variable is unset or set to an empty value.
+func (e *Environment) Get(key string) string {
+	return e.vars[normalize(key)]
+}
+
+// Set normalizes key and sets it to value.
+//
+// If key is the BAGGAGE variable, the list members exceeding the baggage
+// limits are dropped and reported to the global error handler.
+//
+// If another key with the same normalized name was set before, its value is
+// replaced and the key is reported by [Environment.Collisions].
+func (e *Environment) Set(key, value string) {
+	k := normalize(key)
+	if prev, ok := e.injected[k]; ok && prev != key {
+		e.collisions = append(e.collisions, prev)
+	}
+	if e.injected == nil {
+		e.injected = make(map[string]string)
+	}
+	e.injected[k] = key
+
+	if k == baggageKey {
+		value = e.limitBaggage(value)
+	}
+	e.vars[k] = value
+}
+
+// limitBaggage returns the list members of the baggage value that fit the
+// limits, in order.
+func (e *Environment) limitBaggage(value string) string {
+	if value == "" {
+		return value
+	}
+	var (
+		kept    []string
+		size    int
+		dropped int
+	)
+	for member := range strings.SplitSeq(value, ",") {
+		n := len(member)
+		if len(kept) > 0 {
+			// The separating comma.
+			n++
+		}
+		if len(kept) >= e.cfg.maxBaggageMembers || size+n > e.cfg.maxBaggageBytes {
+			dropped++
+			continue
+		}
+		kept = append(kept, member)
+		size += n
+	}
+	if dropped == 0 {
+		return value
+	}
+	otel.Handle(fmt.Errorf("envcar: %d baggage list-members dropped: limits of %d bytes and %d list-members exceeded", dropped, e.cfg.maxBaggageBytes, e.cfg.maxBaggageMembers))
+	return strings.Join(kept, ",")
+}
+
+// Keys returns the normalized variable names of the environment, sorted.
+func (e *Environment) Keys() []string {
+	keys := make([]string, 0, len(e.vars))
+	for k := range e.vars {
+		if normalized(k) {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Environ returns the environment in the "key=value" form of [os.Environ],
+// sorted by name. It includes the variables whose names are not normalized.
+//
+// The result can be used as the Env of an [os/exec.Cmd].
+func (e *Environment) Environ() []string {
+	environ := make([]string, 0, len(e.vars))
+	for _, k := range slices.Sorted(maps.Keys(e.vars)) {
+		environ = append(environ, k+"="+e.vars[k])
+	}
+	return environ
+}
+
+// Collisions returns the keys passed to [Environment.Set] whose values were
+// lost because a later key has the same normalized name, in the order they
+// were lost.
+func (e *Environment) Collisions() []string {
+	return slices.Clone(e.collisions)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package envcar_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"go.opentelemetry.io/contrib/propagators/envcar"
+)
+
+type errorHandler struct {
+	errs []error
+}
+
+func (h *errorHandler) Handle(err error) { h.errs = append(h.errs, err) }
+
+func TestEnvironmentExtract(t *testing.T) {
+	env := envcar.NewEnvironment([]string{
+		"PATH=/usr/bin",
+		"TRACEPARENT=00-000000000000007b00000000000001c8-000000000000007b-01",
+		"traceparent=00-000000000000007b00000000000001c8-00000000000001c8-01",
+		"INVALID",
+	})
+	ctx := prop.Extract(t.Context(), env)
+
+	want := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	})
+	assert.Equal(t, want, trace.SpanContextFromContext(ctx))
+	assert.Equal(t, []string{"PATH", "TRACEPARENT"}, env.Keys())
+}
+
+func TestEnvironmentFromMap(t *testing.T) {
+	m := map[string]string{"TRACESTATE": "key1=value1"}
+	env := envcar.NewEnvironmentFromMap(m)
+	env.Set("tracestate", "key2=value2")
+
+	assert.Equal(t, "key2=value2", env.Get("tracestate"))
+	assert.Equal(t, "key1=value1", m["TRACESTATE"], "map should not be modified")
+}
+
+func TestEnvironmentInject(t *testing.T) {
+	env := envcar.NewEnvironment([]string{"PATH=/usr/bin", "TRACEPARENT=stale"})
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	prop.Inject(trace.ContextWithSpanContext(t.Context(), sc), env)
+
+	assert.Equal(t, []string{
+		"PATH=/usr/bin",
+		"TRACEPARENT=00-000000000000007b00000000000001c8-000000000000007b-01",
+	}, env.Environ())
+	assert.Empty(t, env.Collisions())
+}
+
+func TestEnvironmentCollisions(t *testing.T) {
+	env := envcar.NewEnvironment(nil)
+	env.Set("uber-trace-id", "a")
+	env.Set("uber-trace-id", "b")
+	assert.Empty(t, env.Collisions(), "setting the same key is not a collision")
+
+	env.Set("uber_trace_id", "c")
+	env.Set("Uber-Trace-Id", "d")
+	assert.Equal(t, []string{"uber-trace-id", "uber_trace_id"}, env.Collisions())
+	assert.Equal(t, "d", env.Get("UBER_TRACE_ID"))
+}
+
+func TestEnvironmentBaggageLimits(t *testing.T) {
+	orig := otel.GetErrorHandler()
+	t.Cleanup(func() { otel.SetErrorHandler(orig) })
+	h := &errorHandler{}
+	otel.SetErrorHandler(h)
+
+	members := make([]baggage.Member, 0, 4)
+	for _, kv := range [][2]string{{"a", "1"}, {"b", strings.Repeat("x", 20)}, {"c", "3"}, {"d", "4"}} {
+		m, err := baggage.NewMember(kv[0], kv[1])
+		require.NoError(t, err)
+		members = append(members, m)
+	}
+	bag, err := baggage.New(members...)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(t.Context(), bag)
+
+	env := envcar.NewEnvironment(nil)
+	propagation.Baggage{}.Inject(ctx, env)
+	got, err := baggage.Parse(env.Get("baggage"))
+	require.NoError(t, err)
+	assert.Equal(t, bag.Len(), got.Len(), "default limits")
+	assert.Empty(t, h.errs)
+
+	env = envcar.NewEnvironment(nil, envcar.WithMaxBaggageBytes(16), envcar.WithMaxBaggageMembers(2))
+	propagation.Baggage{}.Inject(ctx, env)
+	got, err = baggage.Parse(env.Get("baggage"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.Len())
+	assert.LessOrEqual(t, len(env.Get("baggage")), 16)
+	assert.Empty(t, got.Member("b").Key(), "oversized member should be dropped")
+	require.Len(t, h.errs, 1)
+	assert.ErrorContains(t, h.errs[0], "2 baggage list-members dropped")
+}
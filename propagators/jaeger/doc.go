@@ -3,4 +3,8 @@
 
 // Package jaeger implements the Jaeger propagator specification as defined at
 // https://www.jaegertracing.io/sdk-migration/#propagation-format
+//
+// The baggage is propagated with the uberctx- prefixed headers of the Jaeger
+// clients, and the jaeger-debug-id header forces the sampling of the extracted
+// span context.
 package jaeger
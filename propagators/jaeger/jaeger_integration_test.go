@@ -9,6 +9,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 
@@ -94,3 +96,69 @@ func TestInjectJaeger(t *testing.T) {
 		}
 	}
 }
+
+func TestJaegerBaggageRoundTrip(t *testing.T) {
+	member, err := baggage.NewMemberRaw("user-id", "a b/ü=1")
+	require.NoError(t, err)
+	bags, err := baggage.New(member)
+	require.NoError(t, err)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID32,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	})
+	ctx := trace.ContextWithSpanContext(baggage.ContextWithBaggage(t.Context(), bags), sc)
+
+	propagator := jaeger.Jaeger{}
+	header := http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(header))
+	assert.Equal(t, "a+b%2F%C3%BC%3D1", header.Get("uberctx-user-id"))
+
+	ctx = propagator.Extract(t.Context(), propagation.HeaderCarrier(header))
+	assert.Equal(t, bags, baggage.FromContext(ctx))
+	assert.True(t, trace.SpanContextFromContext(ctx).Equal(sc.WithRemote(true)))
+}
+
+func TestJaegerExtractBaggage(t *testing.T) {
+	existing, err := baggage.NewMemberRaw("existing", "value")
+	require.NoError(t, err)
+	bags, err := baggage.New(existing)
+	require.NoError(t, err)
+	ctx := baggage.ContextWithBaggage(t.Context(), bags)
+
+	header := http.Header{}
+	// Baggage is extracted even without a trace context.
+	header.Set("uberctx-Key", "some%20value")
+	header.Set("uberctx-", "empty key")
+	ctx = jaeger.Jaeger{}.Extract(ctx, propagation.HeaderCarrier(header))
+
+	got := baggage.FromContext(ctx)
+	assert.Equal(t, 2, got.Len())
+	assert.Equal(t, "value", got.Member("existing").Value())
+	assert.Equal(t, "some value", got.Member("key").Value())
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+}
+
+func TestJaegerExtractDebugID(t *testing.T) {
+	header := http.Header{}
+	header.Set("uber-trace-id", traceID32Str+":"+spanIDStr+":0:0")
+	header.Set("jaeger-debug-id", "some-correlation-id")
+
+	propagator := jaeger.Jaeger{}
+	ctx := propagator.Extract(t.Context(), propagation.HeaderCarrier(header))
+	sc := trace.SpanContextFromContext(ctx)
+	assert.True(t, sc.IsSampled(), "debug id should force sampling")
+	assert.True(t, jaeger.DebugFromContext(ctx))
+
+	// The debug flag is propagated downstream.
+	out := http.Header{}
+	propagator.Inject(ctx, propagation.HeaderCarrier(out))
+	assert.Equal(t, traceID32Str+":"+spanIDStr+":0:3", out.Get("uber-trace-id"))
+
+	// The debug id alone does not create a span context.
+	header.Del("uber-trace-id")
+	ctx = propagator.Extract(t.Context(), propagation.HeaderCarrier(header))
+	assert.False(t, trace.SpanContextFromContext(ctx).IsValid())
+	assert.False(t, jaeger.DebugFromContext(ctx))
+}
@@ -7,15 +7,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/baggage"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	jaegerHeader        = "uber-trace-id"
+	debugIDHeader       = "jaeger-debug-id"
+	baggageHeaderPrefix = "uberctx-"
 	separator           = ":"
 	traceID128bitsWidth = 128 / 4
 	spanIDWidth         = 64 / 4
@@ -45,6 +50,10 @@ var (
 // Jaeger format:
 //
 // uber-trace-id: {trace-id}:{span-id}:{parent-span-id}:{flags}.
+//
+// The baggage is propagated with one uberctx-{key} header per member, holding
+// the URL-encoded value of the member. A jaeger-debug-id header forces the
+// extracted span context to be sampled and flagged as debug.
 type Jaeger struct{}
 
 var _ propagation.TextMapPropagator = &Jaeger{}
@@ -68,14 +77,34 @@ func (Jaeger) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	}
 
 	carrier.Set(jaegerHeader, strings.Join(headers, separator))
+
+	for _, m := range baggage.FromContext(ctx).Members() {
+		carrier.Set(baggageHeaderPrefix+m.Key(), url.QueryEscape(m.Value()))
+	}
 }
 
 // Extract extracts a context from the carrier if it contains Jaeger headers.
+//
+// The baggage extracted from uberctx- headers is merged into the baggage of
+// ctx.
 func (Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	bags, err := extractBags(ctx, carrier)
+	if err != nil {
+		otel.Handle(err)
+	}
+	if bags.Len() != 0 {
+		ctx = baggage.ContextWithBaggage(ctx, bags)
+	}
+
 	// extract tracing information
 	if h := carrier.Get(jaegerHeader); h != "" {
 		ctx, sc, err := extract(ctx, h)
 		if err == nil && sc.IsValid() {
+			if carrier.Get(debugIDHeader) != "" {
+				// The jaeger-debug-id header forces sampling.
+				sc = sc.WithTraceFlags(sc.TraceFlags() | trace.FlagsSampled)
+				ctx = withDebug(ctx, true)
+			}
 			return trace.ContextWithRemoteSpanContext(ctx, sc)
 		}
 	}
@@ -83,6 +112,32 @@ func (Jaeger) Extract(ctx context.Context, carrier propagation.TextMapCarrier) c
 	return ctx
 }
 
+// extractBags returns the baggage of ctx with the members of the uberctx-
+// headers of carrier.
+func extractBags(ctx context.Context, carrier propagation.TextMapCarrier) (baggage.Baggage, error) {
+	bags := baggage.FromContext(ctx)
+	var errs []error
+	for _, key := range carrier.Keys() {
+		lowerKey := strings.ToLower(key)
+		if !strings.HasPrefix(lowerKey, baggageHeaderPrefix) {
+			continue
+		}
+		value := carrier.Get(key)
+		if v, err := url.QueryUnescape(value); err == nil {
+			value = v
+		}
+		member, err := baggage.NewMemberRaw(strings.TrimPrefix(lowerKey, baggageHeaderPrefix), value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if bags, err = bags.SetMember(member); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return bags, errors.Join(errs...)
+}
+
 func extract(ctx context.Context, headerVal string) (context.Context, trace.SpanContext, error) {
 	var (
 		scc = trace.SpanContextConfig{}
@@ -1,7 +1,8 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
-// Package opencensus provides an OpenCensus trace context propagator.
+// Package opencensus provides the OpenCensus binary and Google Cloud
+// X-Cloud-Trace-Context trace context propagators.
 package opencensus
 
 import (
@@ -43,7 +44,8 @@ func (Binary) Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
 	carrier.Set(binaryHeader, string(h))
 }
 
-// Extract extracts the SpanContext from the TextMapCarrier.
+// Extract extracts the SpanContext from the TextMapCarrier. The trace state of
+// a span context of the same trace already in ctx is preserved.
 func (b Binary) Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
 	state := carrier.Get(binaryHeader)
 	if state != "" {
@@ -54,7 +56,7 @@ func (b Binary) Extract(ctx context.Context, carrier propagation.TextMapCarrier)
 	if !sc.IsValid() {
 		return ctx
 	}
-	return trace.ContextWithRemoteSpanContext(ctx, sc)
+	return trace.ContextWithRemoteSpanContext(ctx, withTraceState(ctx, sc))
 }
 
 func (Binary) extract(carrier propagation.TextMapCarrier) trace.SpanContext {
@@ -73,3 +75,14 @@ func (Binary) extract(carrier propagation.TextMapCarrier) trace.SpanContext {
 func (Binary) Fields() []string {
 	return []string{binaryHeader}
 }
+
+// withTraceState returns sc with the trace state of the span context in ctx
+// if it is of the same trace. The OpenCensus binary format does not carry the
+// trace state, this keeps the one extracted by another propagator, for
+// example the W3C Trace Context propagator, when composed.
+func withTraceState(ctx context.Context, sc trace.SpanContext) trace.SpanContext {
+	if prev := trace.SpanContextFromContext(ctx); prev.TraceID() == sc.TraceID() {
+		return sc.WithTraceState(prev.TraceState())
+	}
+	return sc
+}
@@ -146,3 +146,37 @@ func TestExtract(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractPreservesTraceState(t *testing.T) {
+	ts, err := trace.ParseTraceState("vendor=value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		TraceState: ts,
+	})
+
+	for _, prop := range []propagation.TextMapPropagator{Binary{}, CloudTraceContext{}} {
+		header := http.Header{}
+		prop.Inject(trace.ContextWithSpanContext(t.Context(), sc), propagation.HeaderCarrier(header))
+
+		// A span context of the same trace, as extracted by a W3C Trace
+		// Context propagator, holds the trace state.
+		ctx := trace.ContextWithRemoteSpanContext(t.Context(), sc.WithSpanID(childSpanID))
+		got := trace.SpanContextFromContext(prop.Extract(ctx, propagation.HeaderCarrier(header)))
+		if !got.Equal(sc.WithRemote(true)) {
+			t.Errorf("%T: got %v, want %v", prop, got, sc)
+		}
+
+		// The trace state of another trace is not used.
+		other := sc.WithTraceID(trace.TraceID{1})
+		ctx = trace.ContextWithRemoteSpanContext(t.Context(), other)
+		got = trace.SpanContextFromContext(prop.Extract(ctx, propagation.HeaderCarrier(header)))
+		if got.TraceState().Len() != 0 {
+			t.Errorf("%T: got trace state %v, want empty", prop, got.TraceState())
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package opencensus
+
+import "go.opentelemetry.io/contrib/propagators/cloudtrace"
+
+// CloudTraceContext is the Google Cloud X-Cloud-Trace-Context propagator used
+// by the OpenCensus Stackdriver propagation, see [cloudtrace.Propagator].
+type CloudTraceContext = cloudtrace.Propagator
@@ -20,6 +20,7 @@ require (
 	github.com/golang/protobuf v1.5.4 // indirect
 	github.com/google/uuid v1.6.0 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
+	go.opentelemetry.io/contrib/propagators/cloudtrace v0.70.0 // indirect
 	go.opentelemetry.io/otel/bridge/opencensus v1.45.0 // indirect
 	go.opentelemetry.io/otel/metric v1.45.0 // indirect
 	go.opentelemetry.io/otel/sdk/metric v1.45.0 // indirect
@@ -33,5 +34,6 @@ require (
 
 replace (
 	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc => ../../../instrumentation/google.golang.org/grpc/otelgrpc
+	go.opentelemetry.io/contrib/propagators/cloudtrace => ../../cloudtrace
 	go.opentelemetry.io/contrib/propagators/opencensus => ../
 )
@@ -6,6 +6,7 @@ require (
 	github.com/google/go-cmp v0.7.0
 	github.com/stretchr/testify v1.11.1
 	go.opencensus.io v0.24.0
+	go.opentelemetry.io/contrib/propagators/cloudtrace v0.70.0
 	go.opentelemetry.io/otel v1.45.0
 	go.opentelemetry.io/otel/bridge/opencensus v1.45.0
 	go.opentelemetry.io/otel/trace v1.45.0
@@ -26,3 +27,5 @@ require (
 	golang.org/x/sys v0.47.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace go.opentelemetry.io/contrib/propagators/cloudtrace => ../cloudtrace